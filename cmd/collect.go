@@ -1,16 +1,23 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
+	yamlfact "github.com/salsadigitalauorg/shipshape/pkg/fact/yaml"
 	"github.com/salsadigitalauorg/shipshape/pkg/output"
 	"github.com/salsadigitalauorg/shipshape/pkg/shipshape"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
 )
 
 var collectCmd = &cobra.Command{
@@ -19,76 +26,178 @@ var collectCmd = &cobra.Command{
 	Long: `Collect all facts or only the one specified and
 output them in the format specified`,
 	PreRun: func(cmd *cobra.Command, args []string) {
+		if !utils.StringSliceContains(collectOutputFormats, collectOutputFormat) {
+			log.Fatalf("unsupported output format '%s', expected one of %s",
+				collectOutputFormat, strings.Join(collectOutputFormats, ", "))
+		}
 		runCmd.PreRun(cmd, args)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		shipshape.FactsOnly = true
 		runCmd.Run(cmd, args)
-		for _, f := range fact.Manager().GetPlugins() {
-			if shouldSkipFact(f) {
-				continue
+		if snapshotFile != "" {
+			if err := fact.Manager().SaveSnapshot(snapshotFile); err != nil {
+				log.Fatal(err)
 			}
+		}
 
-			log.WithFields(log.Fields{
-				"fact":   f.GetName(),
-				"format": f.GetFormat(),
-			}).Debug("printing collected fact")
-			fmt.Printf("%s:", f.GetName())
-			switch f.GetFormat() {
-
-			case data.FormatMapListString:
-				loadedData := data.AsMapListString(f.GetData())
-				for k, vList := range loadedData {
-					fmt.Printf("\n  %s:\n", k)
-					for _, v := range vList {
-						fmt.Printf("    - %s\n", v)
-					}
-				}
-
-			case data.FormatMapString:
-				loadedData := data.AsMapString(f.GetData())
-				fmt.Println()
-				for k, v := range loadedData {
-					if strings.Contains(v, "\n") {
-						fmt.Printf("  %s:\n", k)
-						fmt.Println(output.TabbedMultiline("    ", v))
-					} else {
-						fmt.Printf("  %s: %s\n", k, v)
-					}
-				}
-
-			case data.FormatMapNestedString:
-				loadedData := data.AsMapNestedString(f.GetData())
-				fmt.Println()
-				for k, vMap := range loadedData {
-					fmt.Printf("  %s:\n", k)
-					for k2, v := range vMap {
-						fmt.Printf("    %s: %s\n", k2, v)
-					}
-				}
-
-			case data.FormatString:
-				fmt.Printf(" %s\n", f.GetData())
-
-			case data.FormatRaw:
-				fmt.Println("\n",
-					output.TabbedMultiline("  ", fmt.Sprintf("%s", f.GetData())))
-
-			default:
-				log.WithField("data", fmt.Sprintf("%s", f.GetData())).Warn("collect not yet implemented for this format")
-				fmt.Println("  collect not yet implemented for", f.GetFormat())
-			}
-			fmt.Println()
+		facts := collectedFacts()
+		var err error
+		switch collectOutputFormat {
+		case "json":
+			err = collectedFactsJSON(facts, os.Stdout)
+		case "yaml":
+			err = collectedFactsYAML(facts, os.Stdout)
+		default:
+			err = collectedFactsPretty(facts, os.Stdout)
+		}
+		if err != nil {
+			log.Fatal(err)
 		}
 	},
 }
 
+// collectOutputFormats is the list of supported formats for collected facts.
+var collectOutputFormats = []string{"pretty", "json", "yaml"}
+
+// collectOutputFormat is the format in which collected facts are output.
+var collectOutputFormat string
+
+// snapshotFile is the path to save the collected facts to.
+var snapshotFile string
+
+// collectedFact is the representation of a fact in the collect output.
+type collectedFact struct {
+	Id     string          `json:"id" yaml:"id"`
+	Plugin string          `json:"plugin" yaml:"plugin"`
+	Format data.DataFormat `json:"format" yaml:"format"`
+	Data   interface{}     `json:"data" yaml:"data"`
+	Errors []string        `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// collectedFacts returns the facts to output, sorted by id.
+func collectedFacts() []collectedFact {
+	facts := []collectedFact{}
+	for _, f := range fact.Manager().GetPlugins() {
+		if shouldSkipFact(f) {
+			continue
+		}
+
+		cf := collectedFact{
+			Id:     f.GetId(),
+			Plugin: f.GetName(),
+			Format: f.GetFormat(),
+			Data:   plainData(f),
+		}
+		for _, err := range f.GetErrors() {
+			cf.Errors = append(cf.Errors, err.Error())
+		}
+		facts = append(facts, cf)
+	}
+	sort.Slice(facts, func(i, j int) bool { return facts[i].Id < facts[j].Id })
+	return facts
+}
+
+// plainData converts a fact's data into plain values which can be encoded
+// in a human-readable way: bytes are converted to strings and yaml nodes
+// are decoded.
+func plainData(f fact.Facter) interface{} {
+	if f.GetData() == nil {
+		return nil
+	}
+
+	switch f.GetFormat() {
+	case data.FormatRaw:
+		return string(data.AsBytes(f.GetData()))
+
+	case data.FormatMapBytes:
+		strMap := map[string]string{}
+		for k, v := range data.AsMapBytes(f.GetData()) {
+			strMap[k] = string(v)
+		}
+		return strMap
+
+	case yamlfact.FormatYamlNodes:
+		return yamlNodesData(yamlfact.DataAsYamlNodes(f.GetData()))
+
+	case yamlfact.FormatMapYamlNodes:
+		ifcMap := map[string][]interface{}{}
+		for k, nodes := range yamlfact.DataAsMapYamlNodes(f.GetData()) {
+			ifcMap[k] = yamlNodesData(nodes)
+		}
+		return ifcMap
+	}
+	return f.GetData()
+}
+
+func yamlNodesData(nodes []*yaml.Node) []interface{} {
+	values := []interface{}{}
+	for _, n := range nodes {
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			log.WithError(err).Warn("failed to decode yaml node")
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func collectedFactsJSON(facts []collectedFact, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{"facts": facts})
+}
+
+func collectedFactsYAML(facts []collectedFact, w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	defer enc.Close()
+	return enc.Encode(map[string]interface{}{"facts": facts})
+}
+
+func collectedFactsPretty(facts []collectedFact, w io.Writer) error {
+	for _, f := range facts {
+		log.WithFields(log.Fields{
+			"fact":   f.Id,
+			"format": f.Format,
+		}).Debug("printing collected fact")
+
+		fmt.Fprintf(w, "%s:", f.Id)
+		if s, ok := f.Data.(string); ok {
+			if strings.Contains(s, "\n") {
+				fmt.Fprintln(w)
+				fmt.Fprintln(w, output.TabbedMultiline("  ", s))
+			} else {
+				fmt.Fprintf(w, " %s\n", s)
+			}
+		} else if f.Data != nil {
+			dataYaml, err := yaml.Marshal(f.Data)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, output.TabbedMultiline("  ", string(dataYaml)))
+		} else {
+			fmt.Fprintln(w)
+		}
+
+		if len(f.Errors) > 0 {
+			fmt.Fprintln(w, "  errors:")
+			for _, e := range f.Errors {
+				fmt.Fprintf(w, "    - %s\n", e)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
 func shouldSkipFact(f fact.Facter) bool {
 	if len(fact.OnlyFactNames) == 0 {
 		return false
 	}
 	for _, n := range fact.OnlyFactNames {
-		if f.GetName() == n {
+		if f.GetId() == n {
 			return false
 		}
 	}
@@ -98,5 +207,11 @@ func shouldSkipFact(f fact.Facter) bool {
 func init() {
 	collectCmd.Flags().StringSliceVarP(&fact.OnlyFactNames, "facts", "n",
 		[]string{}, "Collect only these facts")
+	collectCmd.Flags().StringVar(&snapshotFile, "save", "",
+		`Save the collected facts to a snapshot file, which
+can then be analysed using 'run --from-snapshot'`)
+	collectCmd.Flags().StringVarP(&collectOutputFormat, "output-format", "o",
+		"pretty", fmt.Sprintf("Output format [%s]",
+			strings.Join(collectOutputFormats, "|")))
 	rootCmd.AddCommand(collectCmd)
 }
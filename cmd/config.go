@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
 
 	log "github.com/sirupsen/logrus"
@@ -12,8 +14,10 @@ import (
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/connection"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
+	"github.com/salsadigitalauorg/shipshape/pkg/migrate"
 	"github.com/salsadigitalauorg/shipshape/pkg/output"
 	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
 	"github.com/salsadigitalauorg/shipshape/pkg/shipshape"
 )
 
@@ -99,9 +103,108 @@ multiple config files are being merged as expected`,
 	},
 }
 
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validates the configuration",
+	Long: `Validates the configuration files against the schema
+generated from the available plugins, and checks the references between
+facts, analysers and connections`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !config.ConfigFilesExist() {
+			shipshape.Exit(1)
+		}
+
+		sources, err := config.FetchConfigSources(config.Files)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		errs := shipshape.ValidateConfig(sources)
+		if len(errs) == 0 {
+			fmt.Println("Config is valid")
+			return
+		}
+
+		for _, e := range errs {
+			fmt.Println(e.Error())
+		}
+		os.Exit(1)
+	},
+}
+
+// schemaResults determines whether to print the schema of the results
+// instead of the configuration's.
+var schemaResults bool
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Prints the configuration JSON Schema",
+	Long: `Prints the JSON Schema of the configuration, generated from
+the available plugins`,
+	Run: func(cmd *cobra.Command, args []string) {
+		s := shipshape.ConfigSchema()
+		if schemaResults {
+			s = result.Schema()
+		}
+		out, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(out))
+	},
+}
+
+var migrateOutputFile string
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates a v1 configuration to v2",
+	Long: `Converts the final merged v1 configuration to the equivalent v2
+collect & analyse configuration. Checks which cannot be converted are
+added as TODO comments`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !config.ConfigFilesExist() {
+			shipshape.Exit(1)
+		}
+
+		isV2, cfg, _, err := config.ReadAndParseConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if isV2 {
+			log.Fatal("config is already in the v2 format")
+		}
+
+		doc, err := migrate.Migrate(cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if migrateOutputFile == "" {
+			fmt.Print(string(out))
+			return
+		}
+		if err := os.WriteFile(migrateOutputFile, out, 0644); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
 func init() {
+	configSchemaCmd.Flags().BoolVar(&schemaResults, "results", false,
+		"Print the JSON Schema of the results output using the json format")
+	configMigrateCmd.Flags().StringVarP(&migrateOutputFile, "output", "o", "",
+		"File to write the migrated configuration to, instead of stdout")
+
 	configCmd.AddCommand(configListPluginsCmd)
 	configCmd.AddCommand(configListChecksCmd)
 	configCmd.AddCommand(configDumpCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configMigrateCmd)
 	rootCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff old.json new.json",
+	Short: "Compare two result files",
+	Long: `Compare two result files written using the json output format,
+reporting new and resolved breaches, as well as the analysers whose status
+or severity changed.
+
+Exits with code 2 if new breaches are found at or above the --fail-severity
+level.`,
+	Args: cobra.ExactArgs(2),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if !utils.StringSliceContains(output.DiffFormats, diffOutputFormat) {
+			log.Fatalf("unsupported output format '%s', expected one of %s",
+				diffOutputFormat, strings.Join(output.DiffFormats, ", "))
+		}
+		if config.Severity(diffFailSeverity).Level() == 0 {
+			log.Fatalf("unsupported severity '%s'", diffFailSeverity)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		oldRl, err := result.ReadResultList(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		newRl, err := result.ReadResultList(args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		d := result.NewDiff(oldRl, newRl)
+		if err := output.Diff(d, diffOutputFormat, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+
+		failLevel := config.Severity(diffFailSeverity).Level()
+		for _, db := range d.New {
+			if config.Severity(db.Severity).Level() >= failLevel {
+				os.Exit(2)
+			}
+		}
+	},
+}
+
+// diffOutputFormat is the format in which the differences are output.
+var diffOutputFormat string
+
+// diffFailSeverity is the minimum severity of new breaches causing a failure.
+var diffFailSeverity string
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffOutputFormat, "output", "o", "pretty",
+		"Output format ["+strings.Join(output.DiffFormats, "|")+"]")
+	diffCmd.Flags().StringVar(&diffFailSeverity, "fail-severity",
+		string(config.HighSeverity), `The minimum severity of new breaches
+at which the program should exit with an error`)
+
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/flagsprovider"
+	"github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report results.json",
+	Short: "Output stored results",
+	Long: `Output results written using the json output format, in any
+of the supported formats, without collecting or analysing again`,
+	Args: cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		flagsprovider.ApplyEnvironmentOverridesAll()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		rl, err := result.ReadResultList(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Print("outputting results")
+		if err := output.OutputAll(rl, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	flagsprovider.AddFlagsAll(reportCmd)
+
+	rootCmd.AddCommand(reportCmd)
+}
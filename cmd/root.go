@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact"
 	"github.com/salsadigitalauorg/shipshape/pkg/flagsprovider"
 )
 
@@ -57,9 +58,7 @@ func Execute() {
 
 func init() {
 	// Register all flags providers.
-	for pluginName, fp := range flagsprovider.Registry {
-		flagsprovider.FlagProviders[pluginName] = fp()
-	}
+	flagsprovider.RegisterAll()
 
 	// Initial config.
 	rootCmd.PersistentFlags().StringSliceVarP(&config.Files, "file", "f",
@@ -76,6 +75,14 @@ single argument or using --types (-t) multiple times`)
 		false, `Exclude checks requiring a database; overrides
 any db checks specified by '--types'`)
 
+	// Fact collection.
+	rootCmd.PersistentFlags().IntVar(&fact.MaxWorkers, "max-workers",
+		fact.DefaultMaxWorkers, `Maximum number of facts to collect
+concurrently`)
+	rootCmd.PersistentFlags().DurationVar(&fact.DefaultTimeout, "timeout",
+		0, `Maximum duration allowed for collecting each fact,
+unless overridden by the fact's own timeout; 0 means no timeout`)
+
 	// Logging flags.
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "warn",
 		"Level of logs to display")
@@ -32,6 +32,10 @@ var runCmd = &cobra.Command{
 		}
 
 		flagsprovider.ApplyEnvironmentOverridesAll()
+
+		if shipshape.UpdateBaseline && shipshape.BaselineFile == "" {
+			log.Fatal("--update-baseline requires --baseline")
+		}
 	},
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -45,7 +49,7 @@ var runCmd = &cobra.Command{
 		}
 
 		if shipshape.IsV2 {
-			shipshape.RunV2()
+			shipshape.RunV2(cmd.Context())
 
 			// If we're only collecting facts, we don't need to output anything,
 			// but not exit either, since it is then assumed this command was called
@@ -57,6 +61,11 @@ var runCmd = &cobra.Command{
 			shipshape.Run()
 		}
 
+		shipshape.ApplyWaivers()
+		if err := shipshape.ApplyBaseline(); err != nil {
+			log.Fatal(err)
+		}
+
 		log.Print("outputting results")
 		if err := output.OutputAll(&shipshape.RunResultList, os.Stdout); err != nil {
 			log.Fatal(err)
@@ -84,6 +93,19 @@ should exit with an error`)
 		"error-code", "e", false, `Exit with error code if a failure is
 detected (env: SHIPSHAPE_ERROR_ON_FAILURE)`)
 
+	// Offline analysis.
+	runCmd.Flags().StringVar(&shipshape.FromSnapshot, "from-snapshot", "",
+		`Analyse the facts from a snapshot file saved using
+'collect --save', instead of collecting them`)
+
+	// Baseline.
+	runCmd.Flags().StringVar(&shipshape.BaselineFile, "baseline", "",
+		`Suppress the breaches listed in the baseline
+file, e.g, baseline.json`)
+	runCmd.Flags().BoolVar(&shipshape.UpdateBaseline, "update-baseline", false,
+		`Write all the breaches found to the
+baseline file`)
+
 	flagsprovider.AddFlagsAll(runCmd)
 
 	rootCmd.AddCommand(runCmd)
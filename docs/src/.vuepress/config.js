@@ -111,6 +111,7 @@ module.exports = {
               children: [
                 ['/reference/analyse/allowed-list', 'allowed:list'],
                 ['/reference/analyse/equals', 'equals'],
+                ['/reference/analyse/legacy-check', 'legacy:check'],
                 ['/reference/analyse/not-empty', 'not:empty'],
                 ['/reference/analyse/not-equals', 'not:equals'],
                 ['/reference/analyse/regex-match', 'regex:match'],
@@ -1,8 +1,6 @@
 package analyse
 
 import (
-	"regexp"
-
 	log "github.com/sirupsen/logrus"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/breach"
@@ -12,8 +10,7 @@ import (
 
 type AllowedList struct {
 	BaseAnalyser `yaml:",inline"`
-	PackageMatch string `yaml:"package-match"`
-	pkgRegex     *regexp.Regexp
+	PackageMatch string   `yaml:"package-match"`
 	Allowed      []string `yaml:"allowed"`
 	Required     []string `yaml:"required"`
 	Deprecated   []string `yaml:"deprecated"`
@@ -34,9 +31,9 @@ func (p *AllowedList) GetName() string {
 }
 
 func (p *AllowedList) Analyse() {
-	if p.PackageMatch != "" {
-		p.pkgRegex = regexp.MustCompile("^(.[^:@]*)?[:@]?([^ latest$]*)")
-	}
+	// The values are packages, e.g, "bitnami/kubectl:8.0"; the outputs can
+	// then report each breach's package and version.
+	p.Result.Packages = p.PackageMatch != ""
 
 	switch p.input.GetFormat() {
 	case data.FormatListString:
@@ -178,16 +175,9 @@ func (p *AllowedList) Analyse() {
 }
 
 func (p *AllowedList) isAllowed(value string) bool {
-	if p.pkgRegex != nil {
-		match := p.pkgRegex.FindStringSubmatch(value)
-		if len(match) < 1 {
-			return false
-		}
-
-		if !utils.PackageCheckString(p.Allowed, match[1], match[2]) {
-			return false
-		}
-		return true
+	if p.PackageMatch != "" {
+		name, version := utils.ParsePackage(value)
+		return utils.PackageCheckString(p.Allowed, name, version)
 	}
 	for _, a := range p.Allowed {
 		if a == value {
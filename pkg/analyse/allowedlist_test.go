@@ -1,6 +1,7 @@
 package analyse_test
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -389,7 +390,7 @@ func TestAllowedListAnalyse(t *testing.T) {
 				Ignore:      tc.ignore,
 			}
 
-			tc.input.Collect()
+			tc.input.Collect(context.Background())
 			analyser.SetInput(tc.input)
 			analyser.Analyse()
 
@@ -398,3 +399,36 @@ func TestAllowedListAnalyse(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowedListPackageMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	input := testdata.New(
+		"testFacter",
+		data.FormatListString,
+		[]interface{}{"bitnami/kubectl:1.25", "bitnami/postgresql:15", "redis:7"},
+	)
+	input.Collect(context.Background())
+	analyser := NewAllowedList("testAllowedList")
+	analyser.PackageMatch = "true"
+	analyser.Allowed = []string{"bitnami/kubectl:1.24", "bitnami/postgresql@16"}
+	analyser.SetInput(input)
+	analyser.Analyse()
+
+	r := analyser.GetResult()
+	assert.True(r.Packages)
+	assert.ElementsMatch([]breach.Breach{
+		&breach.ValueBreach{
+			BreachType: "value",
+			CheckName:  "testAllowedList",
+			ValueLabel: "disallowed value found",
+			Value:      "bitnami/postgresql:15",
+		},
+		&breach.ValueBreach{
+			BreachType: "value",
+			CheckName:  "testAllowedList",
+			ValueLabel: "disallowed value found",
+			Value:      "redis:7",
+		},
+	}, r.Breaches)
+}
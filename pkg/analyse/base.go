@@ -11,12 +11,13 @@ import (
 // BaseAnalyser provides common fields and functionality for analyse plugins.
 type BaseAnalyser struct {
 	plugin.BasePlugin     `yaml:",inline"`
-	Description           string `yaml:"description"`
-	InputName             string `yaml:"input"`
-	Severity              string `yaml:"severity"`
+	Description           string   `yaml:"description"`
+	Links                 []string `yaml:"links"`
+	InputName             string   `yaml:"input"`
+	Severity              string   `yaml:"severity"`
 	breach.BreachTemplate `yaml:"breach-format"`
-	Result                result.Result
-	Remediation           interface{} `yaml:"remediation"`
+	Result                result.Result `yaml:"-"`
+	Remediation           interface{}   `yaml:"remediation"`
 	input                 fact.Facter
 }
 
@@ -36,6 +37,8 @@ func (p *BaseAnalyser) GetResult() result.Result {
 	if p.Description != "" && p.Result.Name != p.Description {
 		p.Result.Name = p.Description
 	}
+	p.Result.Description = p.Description
+	p.Result.Links = p.Links
 	return p.Result
 }
 
@@ -49,9 +52,29 @@ func (p *BaseAnalyser) GetInput() fact.Facter {
 
 func (p *BaseAnalyser) AddBreach(b breach.Breach) {
 	b.SetCommonValues("", p.GetId(), p.Severity)
+	if b.GetLocation() == nil {
+		b.SetLocation(p.inputLocation(b))
+	}
 	p.Result.Breaches = append(p.Result.Breaches, b)
 }
 
+// inputLocation finds where the breach's key or value was found in the
+// input's data, falling back to the location of the whole data.
+func (p *BaseAnalyser) inputLocation(b breach.Breach) *breach.Location {
+	if p.input == nil {
+		return nil
+	}
+	for _, k := range []string{breach.BreachGetKey(b), breach.BreachGetValue(b)} {
+		if k == "" {
+			continue
+		}
+		if loc := p.input.GetLocation(k); loc != nil {
+			return loc
+		}
+	}
+	return p.input.GetLocation("")
+}
+
 // Default implementations
 func (p *BaseAnalyser) ValidateInput() error {
 	log.WithFields(log.Fields{
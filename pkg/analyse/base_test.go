@@ -0,0 +1,66 @@
+package analyse_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/analyse"
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/data"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact/testdata"
+)
+
+func TestBaseAnalyserAddBreachLocation(t *testing.T) {
+	assert := assert.New(t)
+
+	input := testdata.New("modules", data.FormatListString,
+		[]interface{}{"views_ui", "devel"})
+	input.Collect(context.Background())
+	input.SetLocations(map[string]breach.Location{
+		"":      {File: "config/sync/core.extension.yml", Line: 3, EndLine: 4, Column: 3},
+		"devel": {File: "config/sync/core.extension.yml", Line: 4, Column: 3},
+	})
+
+	analyser := NewAllowedList("modules")
+	analyser.Deprecated = []string{"devel"}
+	analyser.Required = []string{"clamav"}
+	analyser.SetInput(input)
+	analyser.Analyse()
+
+	assert.ElementsMatch([]*breach.Location{
+		{File: "config/sync/core.extension.yml", Line: 4, Column: 3},
+		{File: "config/sync/core.extension.yml", Line: 3, EndLine: 4, Column: 3},
+	}, []*breach.Location{
+		analyser.Result.Breaches[0].GetLocation(),
+		analyser.Result.Breaches[1].GetLocation(),
+	})
+
+	t.Run("explicitLocation", func(t *testing.T) {
+		b := &breach.ValueBreach{
+			Value:    "devel",
+			Location: &breach.Location{File: "composer.json", Line: 10},
+		}
+		analyser.AddBreach(b)
+		assert.Equal(&breach.Location{File: "composer.json", Line: 10}, b.GetLocation())
+	})
+}
+
+func TestBaseAnalyserGetResult(t *testing.T) {
+	assert := assert.New(t)
+
+	analyser := NewNotEmpty("tfa")
+	r := analyser.GetResult()
+	assert.Equal("tfa", r.Name)
+	assert.Equal("", r.Description)
+	assert.Equal("normal", r.Severity)
+	assert.Nil(r.Links)
+
+	analyser.Description = "TFA is enabled"
+	analyser.Links = []string{"https://www.drupal.org/project/tfa"}
+	r = analyser.GetResult()
+	assert.Equal("TFA is enabled", r.Name)
+	assert.Equal("TFA is enabled", r.Description)
+	assert.Equal([]string{"https://www.drupal.org/project/tfa"}, r.Links)
+}
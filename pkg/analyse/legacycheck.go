@@ -0,0 +1,112 @@
+package analyse
+
+import (
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// LegacyCheck runs a v1 check, so that checks which don't have a v2
+// equivalent yet can be used alongside other analysers.
+type LegacyCheck struct {
+	BaseAnalyser `yaml:",inline"`
+	// Type is the v1 check type, as registered in config.ChecksRegistry.
+	Type string `yaml:"type"`
+	// Config is the v1 check configuration.
+	Config map[string]interface{} `yaml:"config"`
+
+	check              config.Check
+	performRemediation bool
+}
+
+//go:generate go run ../../cmd/gen.go analyse-plugin --plugin=LegacyCheck --package=analyse
+
+func init() {
+	Manager().RegisterFactory("legacy:check", func(id string) Analyser {
+		return NewLegacyCheck(id)
+	})
+}
+
+func (p *LegacyCheck) GetName() string {
+	return "legacy:check"
+}
+
+// NewCheck creates the v1 check from its type and configuration. The check's
+// name and severity default to the analyser's description and severity.
+func (p *LegacyCheck) NewCheck() (config.Check, error) {
+	if p.Type == "" {
+		return nil, &plugin.ErrSupportRequired{Plugin: p.Id, SupportType: "check type"}
+	}
+	factory, ok := config.ChecksRegistry[config.CheckType(p.Type)]
+	if !ok {
+		return nil, &plugin.ErrSupportNotFound{
+			Plugin: p.Id, SupportType: "check type", SupportPlugin: p.Type}
+	}
+
+	checkConf := map[string]interface{}{}
+	for k, v := range p.Config {
+		checkConf[k] = v
+	}
+	if _, ok := checkConf["name"]; !ok {
+		checkConf["name"] = p.Description
+		if p.Description == "" {
+			checkConf["name"] = p.Id
+		}
+	}
+	if _, ok := checkConf["severity"]; !ok && p.Severity != "" {
+		checkConf["severity"] = p.Severity
+	}
+
+	// Not catching any errors when marshalling since the yaml content is known.
+	checkYaml, _ := yaml.Marshal(checkConf)
+	c := factory()
+	if err := yaml.Unmarshal(checkYaml, c); err != nil {
+		return nil, err
+	}
+	c.Init(config.CheckType(p.Type))
+	return c, nil
+}
+
+// ValidateInput creates the check; legacy checks fetch their own data, so
+// there is no input to validate.
+func (p *LegacyCheck) ValidateInput() error {
+	c, err := p.NewCheck()
+	if err != nil {
+		return err
+	}
+	p.check = c
+	return nil
+}
+
+func (p *LegacyCheck) PreProcessInput() bool {
+	return p.check != nil
+}
+
+// SetPerformRemediation sets whether the check should remediate its breaches
+// during the analysis.
+func (p *LegacyCheck) SetPerformRemediation(flag bool) {
+	p.performRemediation = flag
+}
+
+// Analyse runs the check the same way as a v1 run does.
+func (p *LegacyCheck) Analyse() {
+	c := p.check
+	contextLogger := log.WithFields(log.Fields{
+		"analyser":   p.GetId(),
+		"check-type": c.GetType(),
+		"check-name": c.GetName(),
+	})
+
+	c.SetPerformRemediation(p.performRemediation)
+	config.ExecuteCheck(c, contextLogger)
+}
+
+func (p *LegacyCheck) GetResult() result.Result {
+	if p.check == nil {
+		return p.BaseAnalyser.GetResult()
+	}
+	return *p.check.GetResult()
+}
@@ -0,0 +1,169 @@
+package analyse_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/analyse"
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/checks/file"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestLegacyCheckInit(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test that the plugin is registered.
+	plugin := Manager().GetFactories()["legacy:check"]("testLegacyCheck")
+	assert.NotNil(plugin)
+	analyser, ok := plugin.(*LegacyCheck)
+	assert.True(ok)
+	assert.Equal("testLegacyCheck", analyser.Id)
+}
+
+func TestLegacyCheckPluginName(t *testing.T) {
+	instance := NewLegacyCheck("testLegacyCheck")
+	assert.Equal(t, "legacy:check", instance.GetName())
+}
+
+func newLegacyCheck(t *testing.T, conf string) *LegacyCheck {
+	t.Helper()
+	p := NewLegacyCheck("illegal-files")
+	if err := yaml.Unmarshal([]byte(conf), p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLegacyCheckNewCheck(t *testing.T) {
+	t.Run("noType", func(t *testing.T) {
+		p := newLegacyCheck(t, `config: {}`)
+		_, err := p.NewCheck()
+		assert.EqualError(t, err, "check type required for 'illegal-files'")
+	})
+
+	t.Run("unknownType", func(t *testing.T) {
+		p := newLegacyCheck(t, `type: not-a-check`)
+		assert.EqualError(t, p.ValidateInput(),
+			"check type 'not-a-check' not found for 'illegal-files'")
+		assert.False(t, p.PreProcessInput())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		assert := assert.New(t)
+		p := newLegacyCheck(t, `
+description: Illegal files
+severity: high
+type: file
+config:
+  path: web
+  disallowed-pattern: '^phpinfo\.php$'
+`)
+		c, err := p.NewCheck()
+		assert.NoError(err)
+		fc := c.(*file.FileCheck)
+		assert.Equal("Illegal files", fc.Name)
+		assert.Equal(config.HighSeverity, fc.Severity)
+		assert.Equal(file.File, fc.GetType())
+		assert.Equal("web", fc.Path)
+	})
+
+	t.Run("configOverrides", func(t *testing.T) {
+		assert := assert.New(t)
+		p := newLegacyCheck(t, `
+description: Illegal files
+severity: high
+type: file
+config:
+  name: Disallowed files
+  severity: low
+`)
+		c, err := p.NewCheck()
+		assert.NoError(err)
+		assert.Equal("Disallowed files", c.GetName())
+		assert.Equal(config.LowSeverity, c.GetSeverity())
+	})
+}
+
+func TestLegacyCheckAnalyse(t *testing.T) {
+	currProjectDir := config.ProjectDir
+	defer func() { config.ProjectDir = currProjectDir }()
+	config.ProjectDir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(config.ProjectDir, "phpinfo.php"), []byte("<?php"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("breach", func(t *testing.T) {
+		assert := assert.New(t)
+		p := newLegacyCheck(t, `
+description: Illegal files
+severity: high
+type: file
+config:
+  disallowed-pattern: '^phpinfo\.php$'
+`)
+		assert.NoError(p.ValidateInput())
+		assert.True(p.PreProcessInput())
+		p.Analyse()
+
+		r := p.GetResult()
+		assert.Equal("Illegal files", r.Name)
+		assert.Equal("file", r.CheckType)
+		assert.Equal("high", r.Severity)
+		assert.ElementsMatch([]breach.Breach{&breach.KeyValuesBreach{
+			BreachType: breach.BreachTypeKeyValues,
+			CheckType:  "file",
+			CheckName:  "Illegal files",
+			Severity:   "high",
+			Key:        "illegal files found",
+			Values:     []string{filepath.Join(config.ProjectDir, "phpinfo.php")},
+		}}, r.Breaches)
+	})
+
+	t.Run("waiver", func(t *testing.T) {
+		assert := assert.New(t)
+		defer Manager().ResetPlugins()
+		p := newLegacyCheck(t, `
+description: Illegal files
+type: file
+config:
+  disallowed-pattern: '^phpinfo\.php$'
+`)
+		assert.NoError(p.ValidateInput())
+		Manager().SetPlugins(map[string]Analyser{"illegal-files": p})
+
+		rl := result.NewResultList(false)
+		for _, r := range Manager().AnalyseAll() {
+			r.DetermineResultStatus(false)
+			rl.AddResult(r)
+		}
+		// The breaches' check name is the v1 check's, while waivers refer
+		// to the analyser's id.
+		rl.ApplyWaivers([]breach.Waiver{{Analyser: "illegal-files", Reason: "test"}}, time.Now())
+		assert.Equal(result.Pass, rl.Results[0].Status)
+		assert.NotNil(rl.Results[0].Breaches[0].GetWaiver())
+	})
+
+	t.Run("pass", func(t *testing.T) {
+		assert := assert.New(t)
+		p := newLegacyCheck(t, `
+type: file
+config:
+  disallowed-pattern: '^adminer\.php$'
+`)
+		assert.NoError(p.ValidateInput())
+		p.Analyse()
+
+		r := p.GetResult()
+		assert.Equal("illegal-files", r.Name)
+		assert.Equal(result.Pass, r.Status)
+		assert.Empty(r.Breaches)
+		assert.Equal([]string{"No illegal files"}, r.Passes)
+	})
+}
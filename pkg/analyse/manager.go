@@ -1,6 +1,8 @@
 package analyse
 
 import (
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
@@ -74,11 +76,14 @@ func (m *manager) ValidateInputs() {
 func (m *manager) AnalyseAll() map[string]result.Result {
 	results := make(map[string]result.Result)
 	for _, plugin := range m.GetPlugins() {
+		start := time.Now()
 		if plugin.PreProcessInput() {
 			plugin.Analyse()
 		}
 
 		result := plugin.GetResult()
+		result.Analyser = plugin.GetId()
+		result.Duration = time.Since(start)
 		results[plugin.GetId()] = result
 
 		log.WithField("analyser", plugin.GetId()).
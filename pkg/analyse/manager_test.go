@@ -147,6 +147,7 @@ func TestAnalyseAll(t *testing.T) {
 			},
 			expectResults: map[string]result.Result{
 				"test": {
+					Analyser: "test",
 					Breaches: []breach.Breach{&breach.KeyValuesBreach{
 						BreachType: "key-values",
 						CheckName:  "test",
@@ -169,6 +170,7 @@ func TestAnalyseAll(t *testing.T) {
 			},
 			expectResults: map[string]result.Result{
 				"test": {
+					Analyser: "test",
 					Breaches: []breach.Breach{&breach.KeyValuesBreach{
 						BreachType: "key-values",
 						CheckName:  "test",
@@ -196,6 +198,11 @@ func TestAnalyseAll(t *testing.T) {
 			assert.Len(Manager().GetErrors(), 0)
 			Manager().SetPlugins(tc.analysers)
 			results := Manager().AnalyseAll()
+			for id, r := range results {
+				assert.NotZero(r.Duration)
+				r.Duration = 0
+				results[id] = r
+			}
 			assert.Equal(tc.expectResults, results)
 		})
 	}
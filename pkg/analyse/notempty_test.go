@@ -1,6 +1,7 @@
 package analyse_test
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -85,7 +86,7 @@ func TestNotEmptyAnalyse(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			analyser := NewNotEmpty(tc.name)
 
-			tc.input.Collect()
+			tc.input.Collect(context.Background())
 			analyser.SetInput(tc.input)
 			analyser.Analyse()
 
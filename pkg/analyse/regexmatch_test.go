@@ -1,6 +1,7 @@
 package analyse_test
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -197,7 +198,7 @@ func TestRegexMatchAnalyse(t *testing.T) {
 			analyser := NewRegexMatch(tc.name)
 			analyser.Pattern = tc.pattern
 
-			tc.input.Collect()
+			tc.input.Collect(context.Background())
 			analyser.SetInput(tc.input)
 			analyser.Analyse()
 
@@ -1,6 +1,7 @@
 package analyse_test
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -212,7 +213,7 @@ func TestRegexNotMatchAnalyse(t *testing.T) {
 			analyser.InputName = tc.inputName
 			analyser.Pattern = tc.pattern
 
-			tc.input.Collect()
+			tc.input.Collect(context.Background())
 			analyser.SetInput(tc.input)
 			analyser.Analyse()
 
@@ -24,3 +24,9 @@ type Analyser interface {
 	Analyse()
 	AddBreach(b breach.Breach)
 }
+
+// SelfRemediator is implemented by analysers which remediate their breaches
+// during the analysis, instead of using the breaches' remediators.
+type SelfRemediator interface {
+	SetPerformRemediation(flag bool)
+}
@@ -1,7 +1,11 @@
 package breach
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
@@ -16,6 +20,33 @@ const (
 	BreachTypeKeyValues BreachType = "key-values"
 )
 
+// Location is where a breach was found in a source file. Lines and columns
+// start at 1; zero values are unknown.
+type Location struct {
+	File      string `json:"file"`
+	Line      int    `json:"line,omitempty"`
+	EndLine   int    `json:"end-line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndColumn int    `json:"end-column,omitempty"`
+}
+
+// String returns the location in the usual file:line:column notation, e.g,
+// "docker-compose.yml:12:5" or "core.extension.yml:4-9".
+func (l Location) String() string {
+	s := l.File
+	if l.Line == 0 {
+		return s
+	}
+	s += fmt.Sprintf(":%d", l.Line)
+	if l.EndLine > l.Line {
+		return s + fmt.Sprintf("-%d", l.EndLine)
+	}
+	if l.Column > 0 {
+		s += fmt.Sprintf(":%d", l.Column)
+	}
+	return s
+}
+
 //go:generate go run ../../cmd/gen.go breach-type --type=Value,KeyValue,KeyValues
 
 // Simple breach with no key.
@@ -24,12 +55,15 @@ const (
 //	"file foo.ext not found": file is the ValueLabel, foo.ext is the Value
 type ValueBreach struct {
 	BreachType                    `json:"breach-type"`
-	CheckType                     string `json:"check-type"`
-	CheckName                     string `json:"check-name"`
-	Severity                      string `json:"severity"`
-	ValueLabel                    string `json:"value-label,omitempty"`
-	Value                         string `json:"value"`
-	ExpectedValue                 string `json:"expected-value,omitempty"`
+	CheckType                     string    `json:"check-type"`
+	CheckName                     string    `json:"check-name"`
+	Severity                      string    `json:"severity"`
+	ValueLabel                    string    `json:"value-label,omitempty"`
+	Value                         string    `json:"value"`
+	ExpectedValue                 string    `json:"expected-value,omitempty"`
+	Location                      *Location `json:"location,omitempty"`
+	Suppressed                    bool      `json:"suppressed,omitempty"`
+	Waiver                        *Waiver   `json:"waiver,omitempty"`
 	remediator                    remediation.Remediator
 	remediation.RemediationResult `json:"remediation,omitempty"`
 }
@@ -51,14 +85,17 @@ func (b ValueBreach) String() string {
 //	  - wordpress is the Value
 type KeyValueBreach struct {
 	BreachType                    `json:"breach-type"`
-	CheckType                     string `json:"check-type"`
-	CheckName                     string `json:"check-name"`
-	Severity                      string `json:"severity"`
-	KeyLabel                      string `json:"key-label,omitempty"`
-	Key                           string `json:"key,omitempty"`
-	ValueLabel                    string `json:"value-label,omitempty"`
-	Value                         string `json:"value"`
-	ExpectedValue                 string `json:"expected-value,omitempty"`
+	CheckType                     string    `json:"check-type"`
+	CheckName                     string    `json:"check-name"`
+	Severity                      string    `json:"severity"`
+	KeyLabel                      string    `json:"key-label,omitempty"`
+	Key                           string    `json:"key,omitempty"`
+	ValueLabel                    string    `json:"value-label,omitempty"`
+	Value                         string    `json:"value"`
+	ExpectedValue                 string    `json:"expected-value,omitempty"`
+	Location                      *Location `json:"location,omitempty"`
+	Suppressed                    bool      `json:"suppressed,omitempty"`
+	Waiver                        *Waiver   `json:"waiver,omitempty"`
 	remediator                    remediation.Remediator
 	remediation.RemediationResult `json:"remediation,omitempty"`
 }
@@ -80,13 +117,16 @@ func (b KeyValueBreach) String() string {
 //	  - [administer site configuration, import configuration] are the Values
 type KeyValuesBreach struct {
 	BreachType                    `json:"breach-type"`
-	CheckType                     string   `json:"check-type"`
-	CheckName                     string   `json:"check-name"`
-	Severity                      string   `json:"severity"`
-	KeyLabel                      string   `json:"key-label,omitempty"`
-	Key                           string   `json:"key,omitempty"`
-	ValueLabel                    string   `json:"value-label,omitempty"`
-	Values                        []string `json:"values"`
+	CheckType                     string    `json:"check-type"`
+	CheckName                     string    `json:"check-name"`
+	Severity                      string    `json:"severity"`
+	KeyLabel                      string    `json:"key-label,omitempty"`
+	Key                           string    `json:"key,omitempty"`
+	ValueLabel                    string    `json:"value-label,omitempty"`
+	Values                        []string  `json:"values"`
+	Location                      *Location `json:"location,omitempty"`
+	Suppressed                    bool      `json:"suppressed,omitempty"`
+	Waiver                        *Waiver   `json:"waiver,omitempty"`
 	remediator                    remediation.Remediator
 	remediation.RemediationResult `json:"remediation,omitempty"`
 }
@@ -152,3 +192,56 @@ func BreachGetExpectedValue(bIfc Breach) string {
 	}
 	return ""
 }
+
+// Fingerprint identifies a breach across runs, using its check name and type,
+// key and value(s). It ignores the breach's location and severity, so that
+// moving or reclassifying a breach doesn't change it.
+func Fingerprint(b Breach) string {
+	values := append([]string{}, BreachGetValues(b)...)
+	sort.Strings(values)
+
+	h := sha256.New()
+	for _, s := range append([]string{
+		b.GetCheckName(),
+		b.GetCheckType(),
+		string(b.GetType()),
+		BreachGetKeyLabel(b),
+		BreachGetKey(b),
+		BreachGetValueLabel(b),
+		BreachGetValue(b),
+	}, values...) {
+		// Separate the fields to avoid collisions between e.g, "ab"+"c" and
+		// "a"+"bc".
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Unmarshal parses a JSON-encoded breach into the concrete type given by its
+// breach-type field.
+func Unmarshal(data []byte) (Breach, error) {
+	t := struct {
+		BreachType BreachType `json:"breach-type"`
+	}{}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	var b Breach
+	switch t.BreachType {
+	case BreachTypeValue:
+		b = &ValueBreach{}
+	case BreachTypeKeyValue:
+		b = &KeyValueBreach{}
+	case BreachTypeKeyValues:
+		b = &KeyValuesBreach{}
+	default:
+		return nil, fmt.Errorf("unknown breach type '%s'", t.BreachType)
+	}
+
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -1,6 +1,7 @@
 package breach_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,6 +128,10 @@ func (b bogusBreach) GetCheckType() string {
 	return ""
 }
 
+func (b bogusBreach) GetLocation() *Location {
+	return nil
+}
+
 func (b bogusBreach) GetRemediator() remediation.Remediator {
 	return b.remediator
 }
@@ -146,13 +151,27 @@ func (b bogusBreach) GetType() BreachType {
 func (b bogusBreach) SetCommonValues(checkType string, checkName string, severity string) {
 }
 
+func (b bogusBreach) SetLocation(l *Location) {}
+
+func (b bogusBreach) IsSuppressed() bool {
+	return false
+}
+
+func (b bogusBreach) SetSuppressed(suppressed bool) {}
+
+func (b bogusBreach) GetWaiver() *Waiver {
+	return nil
+}
+
+func (b bogusBreach) SetWaiver(w *Waiver) {}
+
 func (b bogusBreach) String() string {
 	return ""
 }
 
 func (b bogusBreach) SetRemediator(r remediation.Remediator) {}
 
-func (b bogusBreach) PerformRemediation() {}
+func (b bogusBreach) PerformRemediation(ctx context.Context) {}
 
 func (b bogusBreach) SetRemediation(status remediation.RemediationStatus, msg string) {}
 
@@ -301,3 +320,69 @@ func TestBreachGetters(t *testing.T) {
 		})
 	}
 }
+
+func TestLocationString(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("docker-compose.yml", Location{File: "docker-compose.yml"}.String())
+	assert.Equal("docker-compose.yml:12", Location{File: "docker-compose.yml", Line: 12}.String())
+	assert.Equal("docker-compose.yml:12:5",
+		Location{File: "docker-compose.yml", Line: 12, Column: 5}.String())
+	assert.Equal("core.extension.yml:4-9",
+		Location{File: "core.extension.yml", Line: 4, EndLine: 9, Column: 1}.String())
+}
+
+func TestFingerprint(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &KeyValuesBreach{
+		CheckName: "disallowed-modules",
+		Key:       "config/sync/core.extension.yml",
+		Values:    []string{"devel", "views_ui"},
+	}
+	fp := Fingerprint(b)
+	assert.Len(fp, 64)
+
+	// Location, severity and values order are ignored.
+	assert.Equal(fp, Fingerprint(&KeyValuesBreach{
+		CheckName: "disallowed-modules",
+		Severity:  "high",
+		Key:       "config/sync/core.extension.yml",
+		Values:    []string{"views_ui", "devel"},
+		Location:  &Location{File: "config/sync/core.extension.yml", Line: 3},
+	}))
+	assert.Equal([]string{"devel", "views_ui"}, b.Values)
+
+	assert.NotEqual(fp, Fingerprint(&KeyValuesBreach{
+		CheckName: "disallowed-modules",
+		Key:       "config/sync/core.extension.yml",
+		Values:    []string{"devel"},
+	}))
+	assert.NotEqual(fp, Fingerprint(&KeyValueBreach{
+		CheckName: "disallowed-modules",
+		Key:       "config/sync/core.extension.yml",
+		Value:     "devel",
+	}))
+	assert.NotEqual(Fingerprint(&KeyValueBreach{Key: "ab", ValueLabel: "c"}),
+		Fingerprint(&KeyValueBreach{Key: "a", ValueLabel: "bc"}))
+}
+
+func TestUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := Unmarshal([]byte(`{"breach-type":"key-values","check-name":"permissions",` +
+		`"key":"editor","values":["administer users"],"waiver":{"analyser":"permissions","reason":"ok"}}`))
+	assert.NoError(err)
+	assert.Equal(&KeyValuesBreach{
+		BreachType: BreachTypeKeyValues,
+		CheckName:  "permissions",
+		Key:        "editor",
+		Values:     []string{"administer users"},
+		Waiver:     &Waiver{Analyser: "permissions", Reason: "ok"},
+	}, b)
+
+	_, err = Unmarshal([]byte(`{"breach-type":"foo"}`))
+	assert.EqualError(err, "unknown breach type 'foo'")
+
+	_, err = Unmarshal([]byte(`[]`))
+	assert.Error(err)
+}
@@ -1,18 +1,28 @@
 package breach
 
-import "github.com/salsadigitalauorg/shipshape/pkg/remediation"
+import (
+	"context"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+)
 
 // Breach provides a representation for different breach types.
 type Breach interface {
 	GetCheckName() string
 	GetCheckType() string
+	GetLocation() *Location
 	GetRemediator() remediation.Remediator
 	GetRemediationResult() *remediation.RemediationResult
 	GetSeverity() string
 	GetType() BreachType
+	GetWaiver() *Waiver
+	IsSuppressed() bool
 	SetCommonValues(checkType string, checkName string, severity string)
+	SetLocation(*Location)
+	SetSuppressed(bool)
+	SetWaiver(*Waiver)
 	SetRemediator(remediation.Remediator)
-	PerformRemediation()
+	PerformRemediation(ctx context.Context)
 	SetRemediation(status remediation.RemediationStatus, msg string)
 	String() string
 }
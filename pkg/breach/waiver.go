@@ -0,0 +1,83 @@
+package breach
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WaiverDateFormat is the format of a waiver's expiry date.
+const WaiverDateFormat = "2006-01-02"
+
+// Waiver documents an accepted risk: the breaches of the analyser matching
+// its key are waived, and no longer fail the run, until it expires.
+type Waiver struct {
+	Analyser string `yaml:"analyser" json:"analyser"`
+	// Key is matched against the breaches' key, value or values; all the
+	// analyser's breaches are waived when empty.
+	Key    string `yaml:"key,omitempty" json:"key,omitempty"`
+	Reason string `yaml:"reason" json:"reason"`
+	// Expires is the last day the waiver applies, as YYYY-MM-DD.
+	Expires string `yaml:"expires,omitempty" json:"expires,omitempty"`
+	Owner   string `yaml:"owner,omitempty" json:"owner,omitempty"`
+}
+
+// ExpiresAt returns the time from which the waiver no longer applies, i.e,
+// the end of its expiry day, or the zero time if it doesn't expire.
+func (w Waiver) ExpiresAt() (time.Time, error) {
+	if w.Expires == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(WaiverDateFormat, w.Expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"invalid expiry date '%s' for waiver of '%s', expected YYYY-MM-DD",
+			w.Expires, w.Analyser)
+	}
+	return t.AddDate(0, 0, 1), nil
+}
+
+// Expired determines whether the waiver's expiry date has passed. Waivers
+// with an invalid date are considered expired.
+func (w Waiver) Expired(now time.Time) bool {
+	t, err := w.ExpiresAt()
+	if err != nil {
+		return true
+	}
+	return !t.IsZero() && !now.Before(t)
+}
+
+// Matches determines whether the waiver applies to the breach.
+func (w Waiver) Matches(b Breach) bool {
+	return b.GetCheckName() == w.Analyser && w.MatchesKey(b)
+}
+
+// MatchesKey determines whether the waiver's key matches the breach,
+// regardless of the analyser.
+func (w Waiver) MatchesKey(b Breach) bool {
+	if w.Key == "" || w.Key == BreachGetKey(b) || w.Key == BreachGetValue(b) {
+		return true
+	}
+	for _, v := range BreachGetValues(b) {
+		if w.Key == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the reason for the waiver, followed by its owner and expiry
+// date if set.
+func (w Waiver) String() string {
+	details := []string{}
+	if w.Owner != "" {
+		details = append(details, "owner: "+w.Owner)
+	}
+	if w.Expires != "" {
+		details = append(details, "expires: "+w.Expires)
+	}
+	if len(details) == 0 {
+		return w.Reason
+	}
+	return fmt.Sprintf("%s (%s)", w.Reason, strings.Join(details, ", "))
+}
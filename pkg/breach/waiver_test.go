@@ -0,0 +1,85 @@
+package breach_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/breach"
+)
+
+func TestWaiverExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)
+	assert.False(Waiver{}.Expired(now))
+	assert.False(Waiver{Expires: "2026-12-31"}.Expired(now))
+	assert.True(Waiver{Expires: "2026-12-30"}.Expired(now))
+	assert.True(Waiver{Expires: "2026-12-31"}.Expired(now.Add(time.Hour)))
+	assert.True(Waiver{Expires: "31/12/2026"}.Expired(now))
+
+	_, err := Waiver{Analyser: "tfa", Expires: "31/12/2026"}.ExpiresAt()
+	assert.EqualError(err,
+		"invalid expiry date '31/12/2026' for waiver of 'tfa', expected YYYY-MM-DD")
+}
+
+func TestWaiverMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		waiver   Waiver
+		breach   Breach
+		expected bool
+	}{
+		{
+			name:     "otherAnalyser",
+			waiver:   Waiver{Analyser: "tfa"},
+			breach:   &ValueBreach{CheckName: "modules", Value: "tfa"},
+			expected: false,
+		},
+		{
+			name:     "anyKey",
+			waiver:   Waiver{Analyser: "tfa"},
+			breach:   &ValueBreach{CheckName: "tfa", Value: "tfa"},
+			expected: true,
+		},
+		{
+			name:     "value",
+			waiver:   Waiver{Analyser: "modules", Key: "devel"},
+			breach:   &ValueBreach{CheckName: "modules", Value: "devel"},
+			expected: true,
+		},
+		{
+			name:     "otherValue",
+			waiver:   Waiver{Analyser: "modules", Key: "devel"},
+			breach:   &ValueBreach{CheckName: "modules", Value: "views_ui"},
+			expected: false,
+		},
+		{
+			name:     "key",
+			waiver:   Waiver{Analyser: "images", Key: "nginx"},
+			breach:   &KeyValueBreach{CheckName: "images", Key: "nginx", Value: "nginx:latest"},
+			expected: true,
+		},
+		{
+			name:   "values",
+			waiver: Waiver{Analyser: "permissions", Key: "administer modules"},
+			breach: &KeyValuesBreach{CheckName: "permissions", Key: "editor",
+				Values: []string{"administer users", "administer modules"}},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.waiver.Matches(tc.breach))
+		})
+	}
+}
+
+func TestWaiverString(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("SSO enforced", Waiver{Reason: "SSO enforced"}.String())
+	assert.Equal("SSO enforced (owner: security, expires: 2026-12-31)",
+		Waiver{Reason: "SSO enforced", Owner: "security", Expires: "2026-12-31"}.String())
+}
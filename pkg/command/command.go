@@ -7,6 +7,7 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"os/exec"
@@ -31,6 +32,13 @@ func NewExecShellCommander(name string, arg ...string) IShellCommand {
 	return &ExecShellCommand{Cmd: execCmd}
 }
 
+// NewExecShellCommanderContext returns a command instance which is killed
+// when the context is done.
+func NewExecShellCommanderContext(ctx context.Context, name string, arg ...string) IShellCommand {
+	execCmd := exec.CommandContext(ctx, name, arg...)
+	return &ExecShellCommand{Cmd: execCmd}
+}
+
 func (c *ExecShellCommand) Output() ([]byte, error) {
 	log.WithField("command", c).Debug("running command")
 	return c.Cmd.Output()
@@ -40,6 +48,9 @@ func (c *ExecShellCommand) Output() ([]byte, error) {
 // testing and mocking.
 var ShellCommander = NewExecShellCommander
 
+// ShellCommanderContext is the context-aware equivalent of ShellCommander.
+var ShellCommanderContext = NewExecShellCommanderContext
+
 // GetMsgFromCommandError attempts to extract the error message from a command
 // run's stderr.
 func GetMsgFromCommandError(err error) string {
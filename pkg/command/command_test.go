@@ -1,10 +1,12 @@
 package command_test
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
 	"github.com/salsadigitalauorg/shipshape/pkg/internal"
@@ -69,6 +71,26 @@ func TestExecReplacement(t *testing.T) {
 	})
 }
 
+func TestShellCommanderContext(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("noTimeout", func(t *testing.T) {
+		out, err := command.ShellCommanderContext(context.Background(), "echo", "foo").Output()
+		assert.NoError(err)
+		assert.Equal("foo\n", string(out))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := command.ShellCommanderContext(ctx, "sleep", "5").Output()
+		assert.Error(err)
+		assert.Less(time.Since(start), 5*time.Second)
+		assert.ErrorIs(ctx.Err(), context.DeadlineExceeded)
+	})
+}
+
 func TestGetMsgFromCommandError(t *testing.T) {
 	assert := assert.New(t)
 
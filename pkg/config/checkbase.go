@@ -3,11 +3,35 @@ package config
 import (
 	"fmt"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
 )
 
+// ExecuteCheck fetches the check's data, runs the check unless fetching the
+// data already determined the outcome, then remediates the breaches if
+// required.
+func ExecuteCheck(c Check, contextLogger *log.Entry) {
+	if c.RequiresData() {
+		contextLogger.Print("fetching data")
+		c.FetchData()
+		c.HasData(true)
+		if len(c.GetResult().Breaches) == 0 {
+			c.UnmarshalDataMap()
+		}
+	}
+	if len(c.GetResult().Breaches) == 0 && len(c.GetResult().Passes) == 0 {
+		contextLogger.Print("running check")
+		c.RunCheck()
+	}
+	if len(c.GetResult().Breaches) > 0 && c.ShouldPerformRemediation() {
+		contextLogger.Print("performing remediation")
+		c.Remediate()
+	}
+}
+
 // Init acts as the constructor of a check and sets some initial values.
 func (c *CheckBase) Init(ct CheckType) {
 	// Default severity is normal.
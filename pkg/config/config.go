@@ -42,40 +42,59 @@ func ReadAndParseConfig() (bool, Config, ConfigV2, error) {
 	return ParseConfigData(configData)
 }
 
+// FetchConfigData fetches the content of the config files, preceded by the
+// content of the files they include.
 func FetchConfigData(files []string) ([][]byte, error) {
-	var err error
+	sources, err := FetchConfigSources(files)
+	if err != nil {
+		return nil, err
+	}
+
 	configData := [][]byte{}
+	for _, src := range sources {
+		configData = append(configData, src.Content)
+	}
+	return configData, nil
+}
+
+// FetchConfigSources fetches the config files, preceded by the files they
+// include, in the order in which they should be merged.
+func FetchConfigSources(files []string) ([]Source, error) {
+	sources := []Source{}
 	for _, f := range files {
-		var data []byte
 		log.WithField("source", f).Info("fetching config")
-		if utils.StringIsUrl(f) {
-			data, err = utils.FetchContentFromUrl(f)
-			if err != nil {
-				log.WithField("url", f).WithError(
-					err).Error("could not fetch config from url")
-				return nil, err
-			}
-		} else {
-			data, err = os.ReadFile(f)
-			if err != nil {
-				log.WithField("file", f).WithError(
-					err).Error("could not fetch config from file")
-				return nil, err
-			}
+		data, err := FetchSource(f)
+		if err != nil {
+			return nil, err
 		}
-		configData = append(configData, data)
+
+		expanded, err := ExpandIncludes(f, data, nil)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, expanded...)
 	}
-	return configData, nil
+	return sources, nil
 }
 
 func ParseConfigData(configData [][]byte) (bool, Config, ConfigV2, error) {
 	cfgV2 := ConfigV2{}
-	data := configData[0]
-	if err := yaml.Unmarshal(data, &cfgV2); err != nil {
-		log.WithError(err).Debug("config not v2-compatible")
+	for i, data := range configData {
+		mrgCfgV2 := ConfigV2{}
+		if err := yaml.Unmarshal(data, &mrgCfgV2); err != nil {
+			// Overrides are merged into the first file; skipping one would
+			// silently run with an incomplete config.
+			if i > 0 {
+				log.WithError(err).Error("could not parse config override")
+				return false, Config{}, ConfigV2{}, err
+			}
+			log.WithError(err).Debug("config not v2-compatible")
+			continue
+		}
+		cfgV2.Merge(mrgCfgV2)
 	}
 
-	if len(cfgV2.Collect) > 0 {
+	if len(cfgV2.Collect) > 0 || len(cfgV2.Analyse) > 0 {
 		log.WithField("fact plugins", len(cfgV2.Collect)).
 			WithField("analyse plugins", len(cfgV2.Analyse)).
 			Debug("v2-config parsed")
@@ -185,6 +204,97 @@ func (cfg *Config) Merge(mrgCfg Config) error {
 	return nil
 }
 
+// Merge consolidates a v2 configuration into the current one.
+//
+// Plugins are merged by id: when the same id is defined with the same plugin,
+// their fields are merged recursively, with the values in mrgCfg taking
+// precedence; when defined with a different plugin, mrgCfg's definition
+// replaces the existing one. Setting an id to null removes it, which allows
+// disabling inherited facts, analysers, connections or outputters. Waivers
+// are appended. Lists of outputters cannot be merged by plugin; they replace
+// the existing ones, or are replaced.
+func (cfg *ConfigV2) Merge(mrgCfg ConfigV2) {
+	cfg.Connections = mergePluginsConfig(cfg.Connections, mrgCfg.Connections)
+	cfg.Collect = mergePluginsConfig(cfg.Collect, mrgCfg.Collect)
+	cfg.Analyse = mergePluginsConfig(cfg.Analyse, mrgCfg.Analyse)
+	cfg.Waivers = append(cfg.Waivers, mrgCfg.Waivers...)
+
+	if mrgCfg.Output == nil {
+		return
+	}
+	mrgOutputs, mrgIsMap := mrgCfg.Output.(map[string]interface{})
+	outputs, isMap := cfg.Output.(map[string]interface{})
+	if !mrgIsMap || (cfg.Output != nil && !isMap) {
+		cfg.Output = mrgCfg.Output
+		return
+	}
+	if outputs == nil {
+		outputs = map[string]interface{}{}
+	}
+	for pluginName, pluginConf := range mrgOutputs {
+		if pluginConf == nil {
+			delete(outputs, pluginName)
+			continue
+		}
+		outputs[pluginName] = mergeValues(outputs[pluginName], pluginConf)
+	}
+	cfg.Output = outputs
+}
+
+func mergePluginsConfig(cfg map[string]map[string]interface{},
+	mrgCfg map[string]map[string]interface{}) map[string]map[string]interface{} {
+	if mrgCfg == nil {
+		return cfg
+	}
+	if cfg == nil {
+		cfg = map[string]map[string]interface{}{}
+	}
+
+	for id, pluginConf := range mrgCfg {
+		if pluginConf == nil {
+			log.WithField("id", id).Debug("removing plugin from config")
+			delete(cfg, id)
+			continue
+		}
+
+		existing, ok := cfg[id]
+		if !ok {
+			cfg[id] = pluginConf
+			continue
+		}
+
+		for pluginName, conf := range pluginConf {
+			if existingConf, ok := existing[pluginName]; ok {
+				cfg[id] = map[string]interface{}{
+					pluginName: mergeValues(existingConf, conf),
+				}
+			} else {
+				cfg[id] = map[string]interface{}{pluginName: conf}
+			}
+		}
+	}
+	return cfg
+}
+
+// mergeValues recursively merges maps, with values from b taking precedence;
+// any other type of value in b replaces the one in a.
+func mergeValues(a interface{}, b interface{}) interface{} {
+	aMap, aOk := a.(map[string]interface{})
+	bMap, bOk := b.(map[string]interface{})
+	if !aOk || !bOk {
+		return b
+	}
+
+	merged := map[string]interface{}{}
+	for k, v := range aMap {
+		merged[k] = v
+	}
+	for k, v := range bMap {
+		merged[k] = mergeValues(merged[k], v)
+	}
+	return merged
+}
+
 // FilterChecksToRun iterates over all the checks and filters them based on
 // a provided list of check types to run or whether to exclude database checks.
 func (cfg *Config) FilterChecksToRun() {
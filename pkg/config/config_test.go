@@ -4,6 +4,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	. "github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/config/testdata/filterchecks"
@@ -92,6 +93,65 @@ checks:
 		assert.Equal("My second test check 2", tc22.Name)
 		assert.Equal("zap", tc22.Bar)
 	})
+
+	t.Run("multipleV2Files", func(t *testing.T) {
+		logrus.SetOutput(io.Discard)
+		base := `
+collect:
+  fact-1:
+    file:read:
+      path: foo
+analyse:
+  analyser-1:
+    not:empty:
+      input: fact-1
+      severity: low
+`
+		override := `
+analyse:
+  analyser-1:
+    not:empty:
+      severity: high
+`
+		isV2, _, cfgV2, err := ParseConfigData([][]byte{[]byte(base), []byte(override)})
+		assert.NoError(err)
+		assert.True(isV2)
+		assert.Equal(map[string]map[string]interface{}{
+			"analyser-1": {"not:empty": map[string]interface{}{
+				"input":    "fact-1",
+				"severity": "high",
+			}},
+		}, cfgV2.Analyse)
+	})
+
+	t.Run("invalidV2Override", func(t *testing.T) {
+		logrus.SetOutput(io.Discard)
+		base := `
+analyse:
+  analyser-1:
+    not:empty:
+      input: fact-1
+`
+		override := `
+analyse: [analyser-1]
+`
+		_, _, _, err := ParseConfigData([][]byte{[]byte(base), []byte(override)})
+		assert.ErrorContains(err, "cannot unmarshal !!seq into map[string]map[string]interface {}")
+	})
+
+	t.Run("v2AnalyseOnly", func(t *testing.T) {
+		logrus.SetOutput(io.Discard)
+		data := `
+analyse:
+  legacy-1:
+    legacy:check:
+      type: test-check-1
+`
+		isV2, _, cfgV2, err := ParseConfigData([][]byte{[]byte(data)})
+		assert.NoError(err)
+		assert.True(isV2)
+		assert.Len(cfgV2.Analyse, 1)
+	})
 }
 
 func TestCheckMapUnmarshalYaml(t *testing.T) {
@@ -306,6 +366,211 @@ func TestMerge(t *testing.T) {
 	)
 }
 
+func TestMergeV2(t *testing.T) {
+	tt := []struct {
+		name     string
+		cfg      string
+		mrgCfg   string
+		expected ConfigV2
+	}{
+		{
+			name: "newIds",
+			cfg: `
+collect:
+  fact-1:
+    file:read:
+      path: foo
+`,
+			mrgCfg: `
+connections:
+  db:
+    mysql:
+      host: localhost
+collect:
+  fact-2:
+    file:read:
+      path: bar
+`,
+			expected: ConfigV2{
+				Connections: map[string]map[string]interface{}{
+					"db": {"mysql": map[string]interface{}{"host": "localhost"}},
+				},
+				Collect: map[string]map[string]interface{}{
+					"fact-1": {"file:read": map[string]interface{}{"path": "foo"}},
+					"fact-2": {"file:read": map[string]interface{}{"path": "bar"}},
+				},
+			},
+		},
+		{
+			name: "overrideFields",
+			cfg: `
+analyse:
+  analyser-1:
+    equals:
+      description: Foo found
+      input: fact-1
+      value: foo
+      severity: low
+output:
+  stdout:
+    format: json
+`,
+			mrgCfg: `
+analyse:
+  analyser-1:
+    equals:
+      severity: high
+output:
+  stdout:
+    format: junit
+`,
+			expected: ConfigV2{
+				Analyse: map[string]map[string]interface{}{
+					"analyser-1": {"equals": map[string]interface{}{
+						"description": "Foo found",
+						"input":       "fact-1",
+						"value":       "foo",
+						"severity":    "high",
+					}},
+				},
+				Output: map[string]interface{}{
+					"stdout": map[string]interface{}{"format": "junit"},
+				},
+			},
+		},
+		{
+			name: "replacePlugin",
+			cfg: `
+analyse:
+  analyser-1:
+    equals:
+      input: fact-1
+      value: foo
+`,
+			mrgCfg: `
+analyse:
+  analyser-1:
+    not:equals:
+      input: fact-1
+      value: bar
+`,
+			expected: ConfigV2{
+				Analyse: map[string]map[string]interface{}{
+					"analyser-1": {"not:equals": map[string]interface{}{
+						"input": "fact-1",
+						"value": "bar",
+					}},
+				},
+			},
+		},
+		{
+			name: "disable",
+			cfg: `
+collect:
+  fact-1:
+    file:read:
+      path: foo
+  fact-2:
+    file:read:
+      path: bar
+analyse:
+  analyser-1:
+    not:empty:
+      input: fact-1
+`,
+			mrgCfg: `
+collect:
+  fact-2: ~
+analyse:
+  analyser-1:
+`,
+			expected: ConfigV2{
+				Collect: map[string]map[string]interface{}{
+					"fact-1": {"file:read": map[string]interface{}{"path": "foo"}},
+				},
+				Analyse: map[string]map[string]interface{}{},
+			},
+		},
+		{
+			name: "outputListReplaces",
+			cfg: `
+output:
+  stdout:
+    format: json
+`,
+			mrgCfg: `
+output:
+  - stdout:
+      format: pretty
+  - file:
+      format: json
+      path: results.json
+`,
+			expected: ConfigV2{
+				Output: []interface{}{
+					map[string]interface{}{"stdout": map[string]interface{}{"format": "pretty"}},
+					map[string]interface{}{"file": map[string]interface{}{
+						"format": "json", "path": "results.json"}},
+				},
+			},
+		},
+		{
+			name: "outputMapReplacesList",
+			cfg: `
+output:
+  - stdout:
+      format: pretty
+`,
+			mrgCfg: `
+output:
+  stdout:
+    format: junit
+`,
+			expected: ConfigV2{
+				Output: map[string]interface{}{
+					"stdout": map[string]interface{}{"format": "junit"},
+				},
+			},
+		},
+		{
+			name: "appendWaivers",
+			cfg: `
+waivers:
+  - analyser: tfa-disabled
+    reason: SSO enforced
+`,
+			mrgCfg: `
+waivers:
+  - analyser: modules
+    key: devel
+    reason: Needed for local development
+    expires: 2026-12-31
+    owner: dev-team
+`,
+			expected: ConfigV2{
+				Waivers: []breach.Waiver{
+					{Analyser: "tfa-disabled", Reason: "SSO enforced"},
+					{Analyser: "modules", Key: "devel", Reason: "Needed for local development",
+						Expires: "2026-12-31", Owner: "dev-team"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			cfg := ConfigV2{}
+			assert.NoError(yaml.Unmarshal([]byte(tc.cfg), &cfg))
+			mrgCfg := ConfigV2{}
+			assert.NoError(yaml.Unmarshal([]byte(tc.mrgCfg), &mrgCfg))
+
+			cfg.Merge(mrgCfg)
+			assert.Equal(tc.expected, cfg)
+		})
+	}
+}
+
 func TestFilterChecksToRun(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+)
+
+// Include is a reference to another config file, local or remote, which is
+// merged before the file including it.
+type Include struct {
+	// Path is the path or url of the file to include. Relative paths are
+	// resolved from the location of the including file.
+	Path string `yaml:"path"`
+	// Parameters are substituted in the included file before it is parsed.
+	Parameters map[string]string `yaml:"parameters"`
+}
+
+// UnmarshalYAML allows an include to be specified as a plain path.
+func (i *Include) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		i.Path = value.Value
+		return nil
+	}
+
+	type rawInclude Include
+	return value.Decode((*rawInclude)(i))
+}
+
+// Source is the content of a config file along with its path or url.
+type Source struct {
+	Path    string
+	Content []byte
+}
+
+// includeConfig holds the keys of a config file related to includes.
+type includeConfig struct {
+	Include []Include `yaml:"include"`
+	// Parameters are the default values for the parameters of the file.
+	Parameters map[string]string `yaml:"parameters"`
+}
+
+// ErrCircularInclude is returned when config files include each other.
+type ErrCircularInclude struct {
+	Path []string
+}
+
+func (e *ErrCircularInclude) Error() string {
+	return fmt.Sprintf("circular include detected: %s",
+		strings.Join(e.Path, " -> "))
+}
+
+var parameterRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// SubstituteParameters replaces the ${name} placeholders in the content
+// with the value of the matching parameter; placeholders for unknown
+// parameters are left untouched.
+func SubstituteParameters(content []byte, params map[string]string) []byte {
+	if len(params) == 0 {
+		return content
+	}
+	return parameterRegex.ReplaceAllFunc(content, func(m []byte) []byte {
+		name := string(parameterRegex.FindSubmatch(m)[1])
+		if val, ok := params[name]; ok {
+			return []byte(val)
+		}
+		return m
+	})
+}
+
+// FetchSource fetches the content of a config file from a path or url.
+func FetchSource(source string) ([]byte, error) {
+	if utils.StringIsUrl(source) {
+		data, err := utils.FetchContentFromUrl(source)
+		if err != nil {
+			log.WithField("url", source).WithError(
+				err).Error("could not fetch config from url")
+			return nil, err
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		log.WithField("file", source).WithError(
+			err).Error("could not fetch config from file")
+		return nil, err
+	}
+	return data, nil
+}
+
+// ResolveIncludePath resolves the path of an included file relative to the
+// file including it.
+func ResolveIncludePath(from string, path string) (string, error) {
+	if utils.StringIsUrl(path) || filepath.IsAbs(path) {
+		return path, nil
+	}
+
+	if utils.StringIsUrl(from) {
+		base, err := url.Parse(from)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(path)
+		if err != nil {
+			return "", err
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	return filepath.Join(filepath.Dir(from), path), nil
+}
+
+// ExpandIncludes returns a config file preceded by the files it includes,
+// recursively, in the order in which they should be merged. Parameters are
+// substituted in the returned content.
+func ExpandIncludes(source string, content []byte, params map[string]string) ([]Source, error) {
+	return expandIncludes(source, content, params, []string{})
+}
+
+func expandIncludes(source string, content []byte, params map[string]string, stack []string) ([]Source, error) {
+	key := source
+	if !utils.StringIsUrl(source) {
+		if abs, err := filepath.Abs(source); err == nil {
+			key = abs
+		}
+	}
+	for i, s := range stack {
+		if s == key {
+			return nil, &ErrCircularInclude{Path: append(stack[i:], key)}
+		}
+	}
+	stack = append(stack, key)
+
+	// Apply the file's default parameters, overridden by the ones passed in.
+	defaults := includeConfig{}
+	if err := yaml.Unmarshal(content, &defaults); err != nil {
+		return nil, fmt.Errorf("could not parse config '%s': %w", source, err)
+	}
+	allParams := map[string]string{}
+	for k, v := range defaults.Parameters {
+		allParams[k] = v
+	}
+	for k, v := range params {
+		allParams[k] = v
+	}
+	content = SubstituteParameters(content, allParams)
+
+	incCfg := includeConfig{}
+	if err := yaml.Unmarshal(content, &incCfg); err != nil {
+		return nil, fmt.Errorf("could not parse config '%s': %w", source, err)
+	}
+
+	sources := []Source{}
+	for _, inc := range incCfg.Include {
+		incSource, err := ResolveIncludePath(source, inc.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithFields(log.Fields{
+			"source":  source,
+			"include": incSource,
+		}).Info("including config")
+		incContent, err := FetchSource(incSource)
+		if err != nil {
+			return nil, err
+		}
+
+		incData, err := expandIncludes(incSource, incContent, inc.Parameters, stack)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, incData...)
+	}
+	return append(sources, Source{Path: source, Content: content}), nil
+}
@@ -0,0 +1,187 @@
+package config_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/config"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSubstituteParameters(t *testing.T) {
+	assert := assert.New(t)
+
+	content := []byte(`path: ${project_dir}/web
+pattern: '.*\.php$'
+command: echo $HOME ${unknown}`)
+	assert.Equal(`path: app/web
+pattern: '.*\.php$'
+command: echo $HOME ${unknown}`,
+		string(SubstituteParameters(content, map[string]string{"project_dir": "app"})))
+}
+
+func TestResolveIncludePath(t *testing.T) {
+	tt := []struct {
+		from     string
+		path     string
+		expected string
+	}{
+		{from: "shipshape.yml", path: "packs/drupal.yml", expected: "packs/drupal.yml"},
+		{from: "config/shipshape.yml", path: "../packs/drupal.yml", expected: "packs/drupal.yml"},
+		{from: "config/shipshape.yml", path: "/packs/drupal.yml", expected: "/packs/drupal.yml"},
+		{
+			from:     "config/shipshape.yml",
+			path:     "https://example.com/drupal.yml",
+			expected: "https://example.com/drupal.yml",
+		},
+		{
+			from:     "https://example.com/packs/all.yml",
+			path:     "drupal.yml",
+			expected: "https://example.com/packs/drupal.yml",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(t *testing.T) {
+			resolved, err := ResolveIncludePath(tc.from, tc.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestFetchConfigDataIncludes(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	t.Run("localWithParameters", func(t *testing.T) {
+		assert := assert.New(t)
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"shipshape.yml": `
+include:
+  - path: packs/drupal.yml
+    parameters:
+      drush_path: vendor/bin/drush
+analyse:
+  install-profile-check:
+    equals:
+      severity: high
+`,
+			"packs/drupal.yml": `
+parameters:
+  drush_path: drush
+  profile: standard
+include:
+  - common.yml
+collect:
+  install-profile:
+    command:
+      cmd: ${drush_path}
+      args: [config:get, core.extension, profile]
+analyse:
+  install-profile-check:
+    equals:
+      input: install-profile
+      value: ${profile}
+      severity: low
+`,
+			"packs/common.yml": `
+collect:
+  php-files:
+    file:lookup:
+      path: web
+`,
+		})
+
+		configData, err := FetchConfigData([]string{filepath.Join(dir, "shipshape.yml")})
+		assert.NoError(err)
+		assert.Len(configData, 3)
+
+		isV2, _, cfgV2, err := ParseConfigData(configData)
+		assert.NoError(err)
+		assert.True(isV2)
+		assert.Equal(map[string]map[string]interface{}{
+			"install-profile": {"command": map[string]interface{}{
+				"cmd":  "vendor/bin/drush",
+				"args": []interface{}{"config:get", "core.extension", "profile"},
+			}},
+			"php-files": {"file:lookup": map[string]interface{}{"path": "web"}},
+		}, cfgV2.Collect)
+		assert.Equal(map[string]map[string]interface{}{
+			"install-profile-check": {"equals": map[string]interface{}{
+				"input":    "install-profile",
+				"value":    "standard",
+				"severity": "high",
+			}},
+		}, cfgV2.Analyse)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		assert := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/packs/all.yml":
+				fmt.Fprint(w, "include: [drupal.yml]\n")
+			case "/packs/drupal.yml":
+				fmt.Fprint(w, "collect:\n  php-files:\n    file:lookup:\n      path: web\n")
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer ts.Close()
+
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"shipshape.yml": fmt.Sprintf("include:\n  - %s/packs/all.yml\n", ts.URL),
+		})
+
+		configData, err := FetchConfigData([]string{filepath.Join(dir, "shipshape.yml")})
+		assert.NoError(err)
+		_, _, cfgV2, err := ParseConfigData(configData)
+		assert.NoError(err)
+		assert.Contains(cfgV2.Collect, "php-files")
+	})
+
+	t.Run("circular", func(t *testing.T) {
+		assert := assert.New(t)
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"a.yml": "include: [b.yml]\n",
+			"b.yml": "include: [a.yml]\n",
+		})
+
+		_, err := FetchConfigData([]string{filepath.Join(dir, "a.yml")})
+		assert.EqualError(err, fmt.Sprintf("circular include detected: %s -> %s -> %s",
+			filepath.Join(dir, "a.yml"), filepath.Join(dir, "b.yml"),
+			filepath.Join(dir, "a.yml")))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"a.yml": "include: [b.yml]\n"})
+
+		_, err := FetchConfigData([]string{filepath.Join(dir, "a.yml")})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -9,11 +9,15 @@ type Config struct {
 	Checks CheckMap `yaml:"checks"`
 }
 
+// ConfigV2 is the v2 configuration. Its Output is either a map of output
+// plugins to their config, or a list of such single-key maps, which allows
+// using a plugin multiple times.
 type ConfigV2 struct {
 	Connections map[string]map[string]interface{} `yaml:"connections"`
 	Collect     map[string]map[string]interface{} `yaml:"collect"`
 	Analyse     map[string]map[string]interface{} `yaml:"analyse"`
-	Output      map[string]interface{}            `yaml:"output"`
+	Output      interface{}                       `yaml:"output"`
+	Waivers     []breach.Waiver                   `yaml:"waivers"`
 }
 
 type Severity string
@@ -25,6 +29,22 @@ const (
 	CriticalSeverity Severity = "critical"
 )
 
+// Level returns the rank of the severity, higher being more severe, or 0 if
+// it is unknown.
+func (s Severity) Level() int {
+	switch s {
+	case LowSeverity:
+		return 1
+	case NormalSeverity:
+		return 2
+	case HighSeverity:
+		return 3
+	case CriticalSeverity:
+		return 4
+	}
+	return 0
+}
+
 type CheckMap map[CheckType][]Check
 
 type CheckType string
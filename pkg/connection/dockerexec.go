@@ -1,6 +1,8 @@
 package connection
 
 import (
+	"context"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
 	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
 )
@@ -31,8 +33,8 @@ func (p *DockerExec) GetName() string {
 	return "docker:exec"
 }
 
-func (p *DockerExec) Run() ([]byte, error) {
+func (p *DockerExec) Run(ctx context.Context) ([]byte, error) {
 	cmdArgs := []string{"exec", p.Container}
 	cmdArgs = append(cmdArgs, p.Command...)
-	return command.ShellCommander("docker", cmdArgs...).Output()
+	return command.ShellCommanderContext(ctx, "docker", cmdArgs...).Output()
 }
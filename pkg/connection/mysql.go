@@ -1,6 +1,7 @@
 package connection
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -40,7 +41,13 @@ func (p *Mysql) GetName() string {
 	return "mysql"
 }
 
-func (p *Mysql) Run() ([]byte, error) {
+// Run prepares the database instance; queries are expected to be run with
+// the same context by the caller.
+func (p *Mysql) Run(ctx context.Context) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if p.Port == "" {
 		p.Port = "3306"
 	}
@@ -1,8 +1,12 @@
 package connection
 
-import "github.com/salsadigitalauorg/shipshape/pkg/plugin"
+import (
+	"context"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
+)
 
 type Connectioner interface {
 	plugin.Plugin
-	Run() ([]byte, error)
+	Run(ctx context.Context) ([]byte, error)
 }
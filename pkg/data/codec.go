@@ -0,0 +1,61 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Decoder decodes JSON-encoded data into the Go type of a data format.
+type Decoder func(raw []byte) (interface{}, error)
+
+// Decoders is the registry of decoders by data format. Packages defining
+// their own data formats should register a decoder for them, so that data
+// can be restored from its JSON representation.
+var Decoders = map[DataFormat]Decoder{
+	FormatNil:             func(raw []byte) (interface{}, error) { return nil, nil },
+	FormatRaw:             DecodeAs[[]byte],
+	FormatString:          DecodeAs[string],
+	FormatListString:      DecodeAs[[]string],
+	FormatListMapString:   DecodeAs[[]map[string]string],
+	FormatMapBytes:        DecodeAs[map[string][]byte],
+	FormatMapString:       DecodeAs[map[string]string],
+	FormatMapListString:   DecodeAs[map[string][]string],
+	FormatMapNestedString: DecodeAs[map[string]map[string]string],
+}
+
+// ErrUnknownFormat is returned when there is no decoder for a data format.
+type ErrUnknownFormat struct {
+	Format DataFormat
+}
+
+func (e *ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("no decoder found for data format '%s'", e.Format)
+}
+
+// DecodeAs decodes JSON-encoded data into the given type.
+func DecodeAs[T any](raw []byte) (interface{}, error) {
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Encode returns the JSON representation of the data. []byte values are
+// encoded as base64 strings.
+func Encode(data interface{}) ([]byte, error) {
+	return json.Marshal(data)
+}
+
+// Decode restores data of the given format from its JSON representation.
+func Decode(format DataFormat, raw []byte) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	decoder, ok := Decoders[format]
+	if !ok {
+		return nil, &ErrUnknownFormat{Format: format}
+	}
+	return decoder(raw)
+}
@@ -0,0 +1,76 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/data"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tt := []struct {
+		name    string
+		format  DataFormat
+		data    interface{}
+		encoded string
+	}{
+		{name: "nil", format: FormatNil, data: nil, encoded: "null"},
+		{name: "raw", format: FormatRaw, data: []byte("foo"), encoded: `"Zm9v"`},
+		{name: "string", format: FormatString, data: "foo", encoded: `"foo"`},
+		{
+			name:    "listString",
+			format:  FormatListString,
+			data:    []string{"foo", "bar"},
+			encoded: `["foo","bar"]`,
+		},
+		{
+			name:    "listMapString",
+			format:  FormatListMapString,
+			data:    []map[string]string{{"foo": "bar"}},
+			encoded: `[{"foo":"bar"}]`,
+		},
+		{
+			name:    "mapBytes",
+			format:  FormatMapBytes,
+			data:    map[string][]byte{"foo.yml": []byte("foo")},
+			encoded: `{"foo.yml":"Zm9v"}`,
+		},
+		{
+			name:    "mapString",
+			format:  FormatMapString,
+			data:    map[string]string{"foo": "bar"},
+			encoded: `{"foo":"bar"}`,
+		},
+		{
+			name:    "mapListString",
+			format:  FormatMapListString,
+			data:    map[string][]string{"foo": {"bar", "baz"}},
+			encoded: `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:    "mapNestedString",
+			format:  FormatMapNestedString,
+			data:    map[string]map[string]string{"foo": {"bar": "baz"}},
+			encoded: `{"foo":{"bar":"baz"}}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			encoded, err := Encode(tc.data)
+			assert.NoError(err)
+			assert.Equal(tc.encoded, string(encoded))
+
+			decoded, err := Decode(tc.format, encoded)
+			assert.NoError(err)
+			assert.Equal(tc.data, decoded)
+		})
+	}
+}
+
+func TestDecodeUnknownFormat(t *testing.T) {
+	_, err := Decode("foo", []byte(`"bar"`))
+	assert.EqualError(t, err, "no decoder found for data format 'foo'")
+}
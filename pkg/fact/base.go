@@ -1,8 +1,13 @@
 package fact
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/connection"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
@@ -15,17 +20,34 @@ type BaseFact struct {
 	ConnectionName       string          `yaml:"connection"`
 	InputName            string          `yaml:"input"`
 	AdditionalInputNames []string        `yaml:"additional-inputs"`
+	// Timeout is the maximum duration allowed for collecting the fact.
+	// Defaults to DefaultTimeout if not set.
+	Timeout time.Duration `yaml:"timeout"`
+	// Publish marks the fact to be published by the outputs supporting it,
+	// e.g, as a Lagoon fact.
+	Publish bool `yaml:"publish"`
 
 	connection       connection.Connectioner
 	input            Facter
 	additionalInputs []Facter
 	data             interface{}
+	// locations holds where the data was found, keyed by the data's keys or
+	// values; the empty key is the location of the whole data.
+	locations map[string]breach.Location
 }
 
 func (p *BaseFact) GetFormat() data.DataFormat {
 	return p.Format
 }
 
+func (p *BaseFact) GetTimeout() time.Duration {
+	return p.Timeout
+}
+
+func (p *BaseFact) ShouldPublish() bool {
+	return p.Publish
+}
+
 func (p *BaseFact) GetConnectionName() string {
 	return p.ConnectionName
 }
@@ -50,10 +72,11 @@ func (p *BaseFact) GetAdditionalInputs() []Facter {
 	return p.additionalInputs
 }
 
+// GetErrors returns the fact's errors along with its input's errors.
 func (p *BaseFact) GetErrors() []error {
 	if p.input != nil {
-		p.AddErrors(p.input.GetErrors()...)
-		return p.BasePlugin.GetErrors()
+		errs := append([]error{}, p.BasePlugin.GetErrors()...)
+		return append(errs, p.input.GetErrors()...)
 	}
 	return p.BasePlugin.GetErrors()
 }
@@ -62,6 +85,19 @@ func (p *BaseFact) GetData() interface{} {
 	return p.data
 }
 
+// GetLocation returns the source location of the data for the given key or
+// value, or nil if it is unknown.
+func (p *BaseFact) GetLocation(key string) *breach.Location {
+	if loc, ok := p.locations[key]; ok {
+		return &loc
+	}
+	return nil
+}
+
+func (p *BaseFact) GetLocations() map[string]breach.Location {
+	return p.locations
+}
+
 func (p *BaseFact) SetConnection(conn connection.Connectioner) {
 	p.connection = conn
 }
@@ -78,6 +114,10 @@ func (p *BaseFact) SetData(data interface{}) {
 	p.data = data
 }
 
+func (p *BaseFact) SetLocations(locations map[string]breach.Location) {
+	p.locations = locations
+}
+
 func (p *BaseFact) SetAdditionalInputs(plugins []Facter) {
 	p.additionalInputs = plugins
 }
@@ -230,4 +270,15 @@ func LoadAdditionalInputs(p Facter) []error {
 }
 
 // Collect is the main method for collecting data from the fact.
-func (p *BaseFact) Collect() {}
+func (p *BaseFact) Collect(ctx context.Context) {}
+
+// ErrTimeout is recorded against a fact when its collection
+// did not complete within the allowed time.
+type ErrTimeout struct {
+	Fact    string
+	Timeout time.Duration
+}
+
+func (e *ErrTimeout) Error() string {
+	return fmt.Sprintf("fact '%s' timed out after %s", e.Fact, e.Timeout)
+}
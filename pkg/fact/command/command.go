@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -46,7 +47,7 @@ func (p *Command) GetName() string {
 	return "command"
 }
 
-func (p *Command) Collect() {
+func (p *Command) Collect(ctx context.Context) {
 	contextLogger := log.WithFields(log.Fields{
 		"fact-plugin": p.GetName(),
 		"fact":        p.GetId(),
@@ -63,7 +64,7 @@ func (p *Command) Collect() {
 		"stderr": "",
 	}
 
-	data, err := command.ShellCommander(p.Cmd, p.Args...).Output()
+	data, err := command.ShellCommanderContext(ctx, p.Cmd, p.Args...).Output()
 	contextLogger.WithFields(log.Fields{
 		"stdout": string(data),
 		"stderr": fmt.Sprintf("%#v", err),
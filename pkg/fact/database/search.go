@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -52,17 +53,19 @@ func (p *Search) SupportedConnections() (plugin.SupportLevel, []string) {
 	return plugin.SupportRequired, []string{"mysql"}
 }
 
-func (p *Search) Collect() {
+func (p *Search) Collect(ctx context.Context) {
 	if p.IdField == "" {
 		p.AddErrors(fmt.Errorf("id-field is required"))
 		return
 	}
 
-	conn := p.GetConnection().(*connection.Mysql)
+	// Facts are collected concurrently and may share the connection, so the
+	// db instance is set on a copy of it.
+	conn := *p.GetConnection().(*connection.Mysql)
 	log.WithField("mysqlConn", conn).Debug("collecting data")
 
 	if len(p.Tables) == 0 {
-		if err := p.fetchTablesColumns(*conn); err != nil {
+		if err := p.fetchTablesColumns(ctx, conn); err != nil {
 			log.WithError(err).Error("failed to fetch tables and columns")
 			return
 		}
@@ -70,7 +73,7 @@ func (p *Search) Collect() {
 	log.WithField("tables", fmt.Sprintf("%+v", p.Tables)).Trace("tables")
 
 	// Execute the connection to get the db instance.
-	if _, err := conn.Run(); err != nil {
+	if _, err := conn.Run(ctx); err != nil {
 		p.AddErrors(err)
 		return
 	}
@@ -86,7 +89,7 @@ func (p *Search) Collect() {
 			}).Trace("searching")
 			ids := []string{}
 			if err := conn.Db.Select(goqu.C(p.IdField)).Distinct().From(table).Where(
-				goqu.C(col).Like(p.Search)).ScanVals(&ids); err != nil {
+				goqu.C(col).Like(p.Search)).ScanValsContext(ctx, &ids); err != nil {
 				log.WithField("err", fmt.Sprintf("%#v", err)).Trace("failed to search")
 				if mErr, ok := err.(*mysql.MySQLError); !ok || mErr.Message != unknownColMsg {
 					p.AddErrors(err)
@@ -108,7 +111,7 @@ func (p *Search) Collect() {
 }
 
 // fetchTablesColumns fetches list of tables and columns from the information_schema db.
-func (p *Search) fetchTablesColumns(conn connection.Mysql) error {
+func (p *Search) fetchTablesColumns(ctx context.Context, conn connection.Mysql) error {
 	origDb := conn.Database
 	conn.Database = "information_schema"
 
@@ -119,7 +122,7 @@ func (p *Search) fetchTablesColumns(conn connection.Mysql) error {
 	}
 
 	// Execute the connection to get the db instance.
-	if _, err := conn.Run(); err != nil {
+	if _, err := conn.Run(ctx); err != nil {
 		p.AddErrors(err)
 		return err
 	}
@@ -128,7 +131,7 @@ func (p *Search) fetchTablesColumns(conn connection.Mysql) error {
 	if err := conn.Db.Select(&tableCols{}).From("columns").Where(goqu.And(
 		goqu.C("table_schema").Eq(origDb),
 		goqu.C("data_type").In([]string{"char", "varchar", "longtext", "longblob"}),
-	)).ScanStructs(&tablesCols); err != nil {
+	)).ScanStructsContext(ctx, &tablesCols); err != nil {
 		p.AddErrors(err)
 		return err
 	}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"errors"
 
 	log "github.com/sirupsen/logrus"
@@ -47,7 +48,7 @@ func (p *DockerCommand) SupportedConnections() (plugin.SupportLevel, []string) {
 	return plugin.SupportRequired, []string{"docker:exec"}
 }
 
-func (p *DockerCommand) Collect() {
+func (p *DockerCommand) Collect(ctx context.Context) {
 	log.WithFields(log.Fields{
 		"fact-plugin":       p.GetName(),
 		"fact":              p.GetId(),
@@ -55,9 +56,11 @@ func (p *DockerCommand) Collect() {
 		"connection-plugin": p.GetConnection().GetName(),
 	}).Debug("collecting data")
 
-	dockerConn := p.GetConnection().(*connection.DockerExec)
+	// Facts are collected concurrently and may share the connection, so the
+	// command is set on a copy of it.
+	dockerConn := *p.GetConnection().(*connection.DockerExec)
 	dockerConn.Command = p.Command
-	rawData, err := dockerConn.Run()
+	rawData, err := dockerConn.Run(ctx)
 	if err != nil {
 		errMsg := command.GetMsgFromCommandError(err)
 		if errMsg == "" {
@@ -0,0 +1,50 @@
+package docker_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/command"
+	"github.com/salsadigitalauorg/shipshape/pkg/connection"
+	. "github.com/salsadigitalauorg/shipshape/pkg/fact/docker"
+	"github.com/salsadigitalauorg/shipshape/pkg/internal"
+)
+
+func TestDockerCommandCollectSharedConnection(t *testing.T) {
+	assert := assert.New(t)
+
+	curShellCommanderContext := command.ShellCommanderContext
+	defer func() { command.ShellCommanderContext = curShellCommanderContext }()
+	command.ShellCommanderContext = func(ctx context.Context, name string, arg ...string) command.IShellCommand {
+		return internal.TestShellCommand{OutputterFunc: func() ([]byte, error) {
+			return []byte(strings.Join(arg, " ")), nil
+		}}
+	}
+
+	conn := connection.NewDockerExec("cli")
+	conn.Container = "app"
+	facts := []*DockerCommand{NewDockerCommand("php"), NewDockerCommand("drush")}
+	facts[0].Command = []string{"php", "-v"}
+	facts[1].Command = []string{"drush", "status"}
+
+	// Facts are collected concurrently; run with -race to detect unsafe
+	// access to the shared connection.
+	var wg sync.WaitGroup
+	for _, f := range facts {
+		f.SetConnection(conn)
+		wg.Add(1)
+		go func(f *DockerCommand) {
+			defer wg.Done()
+			f.Collect(context.Background())
+		}(f)
+	}
+	wg.Wait()
+
+	assert.Equal([]byte("exec app php -v"), facts[0].GetData())
+	assert.Equal([]byte("exec app drush status"), facts[1].GetData())
+	assert.Empty(conn.Command)
+}
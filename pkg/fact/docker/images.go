@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
@@ -78,7 +79,7 @@ func (p *Images) resolveIgnore(envMapMap map[string]map[string]string) {
 	p.Ignore = newIgnore
 }
 
-func (p *Images) Collect() {
+func (p *Images) Collect(ctx context.Context) {
 	contextLogger := log.WithFields(log.Fields{
 		"fact-plugin": p.GetName(),
 		"fact":        p.GetId(),
@@ -1,11 +1,13 @@
 package file
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
@@ -47,7 +49,7 @@ func (p *Lookup) GetName() string {
 	return "file:lookup"
 }
 
-func (p *Lookup) Collect() {
+func (p *Lookup) Collect(ctx context.Context) {
 	contextLogger := log.WithFields(log.Fields{
 		"fact-plugin": p.GetName(),
 		"fact":        p.GetId(),
@@ -66,6 +68,12 @@ func (p *Lookup) Collect() {
 		return
 	}
 
+	locations := map[string]breach.Location{}
+	for _, f := range files {
+		locations[f] = location(f)
+	}
+	p.SetLocations(locations)
+
 	if p.FileNamesOnly {
 		p.Format = data.FormatListString
 		p.SetData(files)
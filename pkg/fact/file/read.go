@@ -1,12 +1,15 @@
 package file
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
@@ -41,7 +44,7 @@ func (p *Read) GetName() string {
 	return "file:read"
 }
 
-func (p *Read) Collect() {
+func (p *Read) Collect(ctx context.Context) {
 	contextLogger := log.WithFields(log.Fields{
 		"fact-plugin": p.GetName(),
 		"fact":        p.GetId(),
@@ -66,4 +69,22 @@ func (p *Read) Collect() {
 		return
 	}
 	p.SetData(fData)
+	p.SetLocations(map[string]breach.Location{"": location(p.Path)})
+}
+
+// location returns the location of a file, relative to the project directory
+// if it is inside it.
+func location(path string) breach.Location {
+	if !filepath.IsAbs(path) {
+		return breach.Location{File: filepath.Clean(path)}
+	}
+	projectDir, err := filepath.Abs(config.ProjectDir)
+	if err != nil {
+		return breach.Location{File: path}
+	}
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return breach.Location{File: path}
+	}
+	return breach.Location{File: rel}
 }
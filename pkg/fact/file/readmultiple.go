@@ -1,12 +1,14 @@
 package file
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
@@ -46,7 +48,7 @@ func (p *ReadMultiple) SupportedInputFormats() (plugin.SupportLevel, []data.Data
 	return plugin.SupportOptional, []data.DataFormat{data.FormatMapString}
 }
 
-func (p *ReadMultiple) Collect() {
+func (p *ReadMultiple) Collect(ctx context.Context) {
 	contextLogger := log.WithFields(log.Fields{
 		"fact-plugin": p.GetName(),
 		"fact":        p.GetId(),
@@ -66,6 +68,7 @@ func (p *ReadMultiple) Collect() {
 		case data.FormatMapString:
 			p.Format = data.FormatMapBytes
 			res := map[string][]byte{}
+			locations := map[string]breach.Location{}
 			filenameMap := data.AsMapString(p.GetInput().GetData())
 			for k, filename := range filenameMap {
 				fullpath := filepath.Join(config.ProjectDir, filename)
@@ -81,14 +84,17 @@ func (p *ReadMultiple) Collect() {
 					continue
 				}
 				res[k] = fData
+				locations[k] = location(filename)
 			}
 			p.SetData(res)
+			p.SetLocations(locations)
 			return
 		}
 	}
 
 	p.Format = data.FormatMapBytes
 	res := map[string][]byte{}
+	locations := map[string]breach.Location{}
 	for _, filename := range p.Files {
 		fullpath := filepath.Join(config.ProjectDir, filename)
 		if _, err := os.Stat(fullpath); errors.Is(err, os.ErrNotExist) {
@@ -104,6 +110,8 @@ func (p *ReadMultiple) Collect() {
 			continue
 		}
 		res[filename] = fData
+		locations[filename] = location(filename)
 	}
 	p.SetData(res)
+	p.SetLocations(locations)
 }
@@ -0,0 +1,143 @@
+package fact
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrCircularDependency is returned when facts depend on each other, directly
+// or indirectly, through their input or additional inputs.
+type ErrCircularDependency struct {
+	Path []string
+}
+
+func (e *ErrCircularDependency) Error() string {
+	return fmt.Sprintf("circular dependency detected between facts: %s",
+		strings.Join(e.Path, " -> "))
+}
+
+// DependencyGraph maps each fact id to the ids of the facts it depends on.
+type DependencyGraph map[string][]string
+
+// Dependencies returns the ids of the facts the given fact depends on,
+// i.e, its input and additional inputs.
+func Dependencies(f Facter) []string {
+	deps := []string{}
+	if f.GetInputName() != "" {
+		deps = append(deps, f.GetInputName())
+	}
+	for _, n := range f.GetAdditionalInputNames() {
+		if n == "" || n == f.GetInputName() {
+			continue
+		}
+		deps = append(deps, n)
+	}
+	return deps
+}
+
+// NewDependencyGraph builds the dependency graph for the given facts and
+// makes sure it contains no cycle.
+//
+// Dependencies on facts that are not in the list are kept in the graph, and
+// are expected to be reported when the fact's inputs are validated.
+func NewDependencyGraph(facts map[string]Facter) (DependencyGraph, error) {
+	g := DependencyGraph{}
+	for id, f := range facts {
+		g[id] = Dependencies(f)
+	}
+
+	if err := g.detectCycle(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Ids returns the sorted list of fact ids in the graph.
+func (g DependencyGraph) Ids() []string {
+	ids := make([]string, 0, len(g))
+	for id := range g {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// WithDependencies returns the sorted list of the given fact ids along with
+// all the facts they depend on, directly or indirectly.
+func (g DependencyGraph) WithDependencies(ids []string) []string {
+	selected := map[string]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		if selected[id] {
+			return
+		}
+		if _, ok := g[id]; !ok {
+			return
+		}
+		selected[id] = true
+		for _, dep := range g[id] {
+			visit(dep)
+		}
+	}
+	for _, id := range ids {
+		visit(id)
+	}
+
+	res := make([]string, 0, len(selected))
+	for id := range selected {
+		res = append(res, id)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// detectCycle walks the graph depth-first and returns an
+// ErrCircularDependency for the first cycle found.
+func (g DependencyGraph) detectCycle() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			// Extract the cycle from the current path.
+			for i, p := range path {
+				if p == id {
+					cycle := append([]string{}, path[i:]...)
+					return &ErrCircularDependency{Path: append(cycle, id)}
+				}
+			}
+		}
+
+		state[id] = visiting
+		path = append(path, id)
+		for _, dep := range g[id] {
+			if _, ok := g[dep]; !ok {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, id := range g.Ids() {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package fact_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/fact"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact/testdata"
+)
+
+func newFacter(id string, input string, additionalInputs ...string) *testdata.TestFacter {
+	f := testdata.New(id, "", nil)
+	f.InputName = input
+	f.AdditionalInputNames = additionalInputs
+	return f
+}
+
+func TestNewDependencyGraph(t *testing.T) {
+	tt := []struct {
+		name          string
+		facts         map[string]Facter
+		expectedGraph DependencyGraph
+		expectedErr   string
+	}{
+		{
+			name:          "noFact",
+			facts:         map[string]Facter{},
+			expectedGraph: DependencyGraph{},
+		},
+		{
+			name: "noDependency",
+			facts: map[string]Facter{
+				"a": newFacter("a", ""),
+				"b": newFacter("b", ""),
+			},
+			expectedGraph: DependencyGraph{"a": {}, "b": {}},
+		},
+		{
+			name: "chain",
+			facts: map[string]Facter{
+				"a": newFacter("a", ""),
+				"b": newFacter("b", "a"),
+				"c": newFacter("c", "b", "a", "b"),
+			},
+			expectedGraph: DependencyGraph{
+				"a": {}, "b": {"a"}, "c": {"b", "a"}},
+		},
+		{
+			name: "unknownDependency",
+			facts: map[string]Facter{
+				"a": newFacter("a", "foo"),
+			},
+			expectedGraph: DependencyGraph{"a": {"foo"}},
+		},
+		{
+			name: "selfReference",
+			facts: map[string]Facter{
+				"a": newFacter("a", "a"),
+			},
+			expectedErr: "circular dependency detected between facts: a -> a",
+		},
+		{
+			name: "circularInput",
+			facts: map[string]Facter{
+				"a": newFacter("a", "c"),
+				"b": newFacter("b", "a"),
+				"c": newFacter("c", "b"),
+			},
+			expectedErr: "circular dependency detected between facts: a -> c -> b -> a",
+		},
+		{
+			name: "circularAdditionalInput",
+			facts: map[string]Facter{
+				"a": newFacter("a", ""),
+				"b": newFacter("b", "a", "c"),
+				"c": newFacter("c", "b"),
+			},
+			expectedErr: "circular dependency detected between facts: b -> c -> b",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			g, err := NewDependencyGraph(tc.facts)
+			if tc.expectedErr != "" {
+				assert.EqualError(err, tc.expectedErr)
+				assert.IsType(&ErrCircularDependency{}, err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedGraph, g)
+		})
+	}
+}
+
+func TestDependencyGraphWithDependencies(t *testing.T) {
+	g := DependencyGraph{
+		"a": {},
+		"b": {"a"},
+		"c": {"b", "foo"},
+		"d": {},
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, g.WithDependencies([]string{"c"}))
+	assert.Equal(t, []string{"a", "d"}, g.WithDependencies([]string{"d", "a"}))
+	assert.Equal(t, []string{}, g.WithDependencies([]string{"foo"}))
+}
@@ -1,7 +1,12 @@
 package fact
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
@@ -17,6 +22,8 @@ type manager struct {
 	*pluginmanager.Manager[Facter]
 	// collected is a list of fact names that have already been collected.
 	collected []string
+	// collectedMu guards collected, since facts are collected concurrently.
+	collectedMu sync.Mutex
 }
 
 var m *manager
@@ -25,6 +32,16 @@ var m *manager
 // If empty, all facts are collected.
 var OnlyFactNames = []string{}
 
+// DefaultMaxWorkers is the default number of facts collected concurrently.
+const DefaultMaxWorkers = 10
+
+// MaxWorkers is the maximum number of facts collected concurrently.
+var MaxWorkers = DefaultMaxWorkers
+
+// DefaultTimeout is the maximum duration allowed for collecting a fact which
+// does not define its own timeout. Zero means no timeout.
+var DefaultTimeout time.Duration
+
 // Manager returns the fact manager.
 func Manager() *manager {
 	if m == nil {
@@ -83,47 +100,100 @@ func (m *manager) ParseConfig(raw map[string]map[string]interface{}) error {
 		}
 	}
 	log.Infof("parsed %d facts", count)
+
+	// Reject circular dependencies early, as a configuration error.
+	if _, err := NewDependencyGraph(m.GetPlugins()); err != nil {
+		return err
+	}
 	return nil
 }
 
-// CollectAllFacts collects all facts.
-func (m *manager) CollectAllFacts() {
-	for name, p := range m.GetPlugins() {
-		if len(OnlyFactNames) > 0 &&
-			!utils.StringSliceContains(OnlyFactNames, name) {
-			continue
-		}
-		m.CollectFact(name, p)
+// CollectAllFacts collects all facts, or only the ones in OnlyFactNames
+// along with their dependencies.
+//
+// A dependency graph is built from the facts' inputs and additional inputs;
+// facts are then collected concurrently, up to MaxWorkers at a time, each
+// one starting only once all of its dependencies have been collected.
+func (m *manager) CollectAllFacts(ctx context.Context) {
+	graph, err := NewDependencyGraph(m.GetPlugins())
+	if err != nil {
+		m.AddErrors(err)
+		log.WithError(err).Error("failed to build facts dependency graph")
+		return
 	}
-}
 
-// CollectFact collects a fact.
-func (m *manager) CollectFact(name string, f Facter) {
-	log.WithField("fact", name).Debug("starting CollectFact process")
-	var inputF Facter
-	if f.GetInputName() != "" {
-		log.WithField("fact", name).
-			WithField("inputName", f.GetInputName()).
-			Debug("collect input")
-		inputF = m.FindPlugin(f.GetInputName())
-		m.CollectFact(f.GetInputName(), inputF)
+	names := graph.Ids()
+	if len(OnlyFactNames) > 0 {
+		names = graph.WithDependencies(OnlyFactNames)
 	}
 
-	if len(f.GetAdditionalInputNames()) > 0 {
-		for _, n := range f.GetAdditionalInputNames() {
-			log.WithField("fact", name).
-				WithField("additionalInputName", n).
-				Debug("collect additional input")
-			inputF = m.FindPlugin(n)
-			m.CollectFact(n, inputF)
-		}
+	workers := MaxWorkers
+	if workers < 1 {
+		workers = 1
 	}
+	log.WithFields(log.Fields{
+		"facts":   names,
+		"workers": workers,
+	}).Debug("collecting facts")
 
-	if inputF != nil && len(inputF.GetErrors()) > 0 {
-		return
+	done := make(map[string]chan struct{}, len(names))
+	for _, name := range names {
+		done[name] = make(chan struct{})
+	}
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer close(done[name])
+
+			// Wait for all dependencies to be collected.
+			for _, dep := range graph[name] {
+				if depDone, ok := done[dep]; ok {
+					<-depDone
+				}
+			}
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			m.CollectFact(ctx, name, m.FindPlugin(name))
+		}(name)
+	}
+	wg.Wait()
+}
+
+// CollectFact collects a single fact. Its dependencies are expected to have
+// already been collected; if any of them failed, the fact is skipped and the
+// input's errors are recorded against it.
+//
+// The fact is given its own timeout, or DefaultTimeout, to complete; if it
+// doesn't, an ErrTimeout is recorded against the fact instead of failing
+// the whole collection, so that dependent analysers can report it.
+func (m *manager) CollectFact(ctx context.Context, name string, f Facter) {
+	log.WithField("fact", name).Debug("starting CollectFact process")
+	for _, n := range Dependencies(f) {
+		inputF := m.FindPlugin(n)
+		if inputF == nil {
+			continue
+		}
+		if inputErrs := inputF.GetErrors(); len(inputErrs) > 0 {
+			log.WithField("fact", name).
+				WithField("input", n).
+				Debug("skipping fact collection due to input errors")
+			f.AddErrors(inputErrs...)
+			return
+		}
+		if !m.isCollected(n) {
+			log.WithField("fact", name).
+				WithField("input", n).
+				Debug("skipping fact collection due to input not collected")
+			return
+		}
 	}
 
-	if utils.StringSliceContains(m.collected, name) {
+	if m.isCollected(name) {
 		return
 	}
 
@@ -148,9 +218,24 @@ func (m *manager) CollectFact(name string, f Facter) {
 		return
 	}
 
+	timeout := f.GetTimeout()
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	collectCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		collectCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	log.WithField("fact", name).Info("collecting fact")
-	f.Collect()
-	if len(f.GetErrors()) > 0 {
+	f.Collect(collectCtx)
+	if errors.Is(collectCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		err := &ErrTimeout{Fact: name, Timeout: timeout}
+		log.WithField("fact", name).WithError(err).Warn("fact collection timed out")
+		f.AddErrors(err)
+	} else if len(f.GetErrors()) > 0 {
 		m.AddErrors(f.GetErrors()...)
 	}
 
@@ -158,5 +243,43 @@ func (m *manager) CollectFact(name string, f Facter) {
 		"fact": name,
 		"data": f.GetData(),
 	}).Trace("collected fact")
+	m.markCollected(name)
+}
+
+func (m *manager) isCollected(name string) bool {
+	m.collectedMu.Lock()
+	defer m.collectedMu.Unlock()
+	return utils.StringSliceContains(m.collected, name)
+}
+
+func (m *manager) markCollected(name string) {
+	m.collectedMu.Lock()
+	defer m.collectedMu.Unlock()
 	m.collected = append(m.collected, name)
 }
+
+// ResetCollected clears the list of collected facts.
+func (m *manager) ResetCollected() {
+	m.collectedMu.Lock()
+	defer m.collectedMu.Unlock()
+	m.collected = []string{}
+}
+
+// GetPublished returns the collected facts marked to be published, sorted by
+// id; facts with errors are left out.
+func (m *manager) GetPublished() []Facter {
+	ids := []string{}
+	for id, f := range m.GetPlugins() {
+		if !f.ShouldPublish() || !m.isCollected(id) || len(f.GetErrors()) > 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	facts := []Facter{}
+	for _, id := range ids {
+		facts = append(facts, m.GetPlugins()[id])
+	}
+	return facts
+}
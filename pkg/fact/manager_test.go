@@ -0,0 +1,256 @@
+package fact_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/data"
+	. "github.com/salsadigitalauorg/shipshape/pkg/fact"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact/testdata"
+	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
+)
+
+// recorder keeps track of the order in which facts are collected and of the
+// maximum number of facts being collected at the same time.
+type recorder struct {
+	mu      sync.Mutex
+	order   []string
+	running int32
+	maxRun  int32
+}
+
+type recordingFacter struct {
+	*testdata.TestFacter
+	rec *recorder
+}
+
+func (p *recordingFacter) SupportedInputFormats() (plugin.SupportLevel, []data.DataFormat) {
+	if p.GetInputName() == "" {
+		return plugin.SupportNone, []data.DataFormat{}
+	}
+	return plugin.SupportRequired, []data.DataFormat{data.FormatString}
+}
+
+func (p *recordingFacter) Collect(ctx context.Context) {
+	running := atomic.AddInt32(&p.rec.running, 1)
+	defer atomic.AddInt32(&p.rec.running, -1)
+	for {
+		maxRun := atomic.LoadInt32(&p.rec.maxRun)
+		if running <= maxRun || atomic.CompareAndSwapInt32(&p.rec.maxRun, maxRun, running) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	p.rec.mu.Lock()
+	p.rec.order = append(p.rec.order, p.GetId())
+	p.rec.mu.Unlock()
+
+	p.SetData(p.GetId())
+}
+
+func newRecordingFacter(rec *recorder, id string, input string, additionalInputs ...string) Facter {
+	f := newFacter(id, input, additionalInputs...)
+	f.Format = data.FormatString
+	return &recordingFacter{TestFacter: f, rec: rec}
+}
+
+func TestCollectAllFacts(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	reset := func() {
+		Manager().ResetPlugins()
+		Manager().ResetErrors()
+		Manager().ResetCollected()
+		OnlyFactNames = []string{}
+		MaxWorkers = DefaultMaxWorkers
+	}
+	defer reset()
+
+	indexOf := func(list []string, item string) int {
+		for i, v := range list {
+			if v == item {
+				return i
+			}
+		}
+		return -1
+	}
+
+	t.Run("dependenciesCollectedFirst", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		rec := &recorder{}
+		Manager().SetPlugins(map[string]Facter{
+			"a": newRecordingFacter(rec, "a", ""),
+			"b": newRecordingFacter(rec, "b", "a"),
+			"c": newRecordingFacter(rec, "c", "b", "a"),
+			"d": newRecordingFacter(rec, "d", ""),
+		})
+		Manager().CollectAllFacts(context.Background())
+
+		assert.Empty(Manager().GetErrors())
+		assert.ElementsMatch([]string{"a", "b", "c", "d"}, rec.order)
+		assert.Less(indexOf(rec.order, "a"), indexOf(rec.order, "b"))
+		assert.Less(indexOf(rec.order, "b"), indexOf(rec.order, "c"))
+		assert.Equal("a", Manager().FindPlugin("c").GetAdditionalInputs()[0].GetData())
+	})
+
+	t.Run("maxWorkers", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		rec := &recorder{}
+		facts := map[string]Facter{}
+		for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+			facts[id] = newRecordingFacter(rec, id, "")
+		}
+		Manager().SetPlugins(facts)
+		MaxWorkers = 2
+		Manager().CollectAllFacts(context.Background())
+
+		assert.Len(rec.order, 6)
+		assert.LessOrEqual(rec.maxRun, int32(2))
+		assert.Greater(rec.maxRun, int32(1))
+	})
+
+	t.Run("onlyFactNames", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		rec := &recorder{}
+		Manager().SetPlugins(map[string]Facter{
+			"a": newRecordingFacter(rec, "a", ""),
+			"b": newRecordingFacter(rec, "b", "a"),
+			"c": newRecordingFacter(rec, "c", ""),
+		})
+		OnlyFactNames = []string{"b"}
+		Manager().CollectAllFacts(context.Background())
+
+		assert.Equal([]string{"a", "b"}, rec.order)
+	})
+
+	t.Run("inputFailure", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		rec := &recorder{}
+		failing := newRecordingFacter(rec, "a", "")
+		failing.(*recordingFacter).SetInputName("inexistent")
+		Manager().SetPlugins(map[string]Facter{
+			"a": failing,
+			"b": newRecordingFacter(rec, "b", "a"),
+		})
+		Manager().CollectAllFacts(context.Background())
+
+		assert.Empty(rec.order)
+		assert.Len(Manager().GetErrors(), 1)
+	})
+
+	t.Run("circularDependency", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		rec := &recorder{}
+		Manager().SetPlugins(map[string]Facter{
+			"a": newRecordingFacter(rec, "a", "b"),
+			"b": newRecordingFacter(rec, "b", "a"),
+		})
+		Manager().CollectAllFacts(context.Background())
+
+		assert.Empty(rec.order)
+		assert.Len(Manager().GetErrors(), 1)
+		assert.IsType(&ErrCircularDependency{}, Manager().GetErrors()[0])
+	})
+}
+
+// blockingFacter never completes its collection until its context is done.
+type blockingFacter struct {
+	*testdata.TestFacter
+}
+
+func (p *blockingFacter) Collect(ctx context.Context) {
+	<-ctx.Done()
+	p.AddErrors(ctx.Err())
+}
+
+func TestCollectAllFactsTimeout(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	reset := func() {
+		Manager().ResetPlugins()
+		Manager().ResetErrors()
+		Manager().ResetCollected()
+		DefaultTimeout = 0
+	}
+	defer reset()
+
+	t.Run("factTimeout", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		rec := &recorder{}
+		slow := &blockingFacter{TestFacter: newFacter("slow", "")}
+		slow.Format = data.FormatString
+		slow.Timeout = 20 * time.Millisecond
+		Manager().SetPlugins(map[string]Facter{
+			"slow":      slow,
+			"dependent": newRecordingFacter(rec, "dependent", "slow"),
+			"other":     newRecordingFacter(rec, "other", ""),
+		})
+		Manager().CollectAllFacts(context.Background())
+
+		// Timeouts are not collection failures.
+		assert.Empty(Manager().GetErrors())
+		assert.Equal([]string{"other"}, rec.order)
+
+		var errTimeout *ErrTimeout
+		assert.ErrorAs(slow.GetErrors()[1], &errTimeout)
+		assert.Equal("fact 'slow' timed out after 20ms", errTimeout.Error())
+		assert.ErrorAs(Manager().FindPlugin("dependent").GetErrors()[1], &errTimeout)
+	})
+
+	t.Run("defaultTimeout", func(t *testing.T) {
+		assert := assert.New(t)
+		reset()
+		slow := &blockingFacter{TestFacter: newFacter("slow", "")}
+		Manager().SetPlugins(map[string]Facter{"slow": slow})
+		DefaultTimeout = 20 * time.Millisecond
+		Manager().CollectAllFacts(context.Background())
+
+		assert.Empty(Manager().GetErrors())
+		assert.EqualError(slow.GetErrors()[1], "fact 'slow' timed out after 20ms")
+	})
+}
+
+func TestParseConfigCircularDependency(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+	defer Manager().ResetPlugins()
+
+	err := Manager().ParseConfig(map[string]map[string]interface{}{
+		"a": {"testdata:testfacter": map[string]interface{}{"input": "b"}},
+		"b": {"testdata:testfacter": map[string]interface{}{"input": "a"}},
+	})
+	assert.EqualError(t, err,
+		"circular dependency detected between facts: a -> b -> a")
+}
+
+func TestParseConfigTimeout(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+	defer Manager().ResetPlugins()
+
+	err := Manager().ParseConfig(map[string]map[string]interface{}{
+		"a": {"testdata:testfacter": map[string]interface{}{"timeout": "1m30s"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, Manager().FindPlugin("a").GetTimeout())
+}
@@ -0,0 +1,174 @@
+package fact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/data"
+)
+
+// SnapshotVersion is the version of the snapshot file format. It is
+// incremented whenever a change is made that older versions of shipshape
+// would not be able to read.
+const SnapshotVersion = 1
+
+// Snapshot is a serialisable record of collected facts, allowing analysers
+// to be run offline against previously collected data.
+type Snapshot struct {
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created-at"`
+	Facts     []SnapshotFact `json:"facts"`
+}
+
+// SnapshotFact is a single fact in a snapshot.
+type SnapshotFact struct {
+	Id     string          `json:"id"`
+	Plugin string          `json:"plugin"`
+	Format data.DataFormat `json:"format"`
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors,omitempty"`
+	// Locations is where the data was found; see BaseFact.GetLocation.
+	Locations map[string]breach.Location `json:"locations,omitempty"`
+	// Publish is whether the fact is to be published; see BaseFact.Publish.
+	Publish bool `json:"publish,omitempty"`
+}
+
+// ErrSnapshotVersion is returned when a snapshot's version is not supported.
+type ErrSnapshotVersion struct {
+	Version int
+}
+
+func (e *ErrSnapshotVersion) Error() string {
+	return fmt.Sprintf("unsupported snapshot version %d, expected %d",
+		e.Version, SnapshotVersion)
+}
+
+// Replayed is a fact restored from a snapshot. It reports the plugin name,
+// format, data and errors of the original fact, and does nothing when
+// collected.
+type Replayed struct {
+	BaseFact
+	plugin string
+}
+
+func (p *Replayed) GetName() string {
+	return p.plugin
+}
+
+func (p *Replayed) Collect(ctx context.Context) {}
+
+// NewSnapshot creates a snapshot of the facts that have been collected or
+// have errors.
+func (m *manager) NewSnapshot() (*Snapshot, error) {
+	s := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		Facts:     []SnapshotFact{},
+	}
+
+	for id, f := range m.GetPlugins() {
+		if !m.isCollected(id) && len(f.GetErrors()) == 0 {
+			continue
+		}
+
+		raw, err := data.Encode(f.GetData())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode data for fact '%s': %w", id, err)
+		}
+
+		sf := SnapshotFact{
+			Id:     id,
+			Plugin: f.GetName(),
+			Format: f.GetFormat(),
+			Data:   raw,
+
+			Locations: f.GetLocations(),
+			Publish:   f.ShouldPublish(),
+		}
+		for _, err := range f.GetErrors() {
+			sf.Errors = append(sf.Errors, err.Error())
+		}
+		s.Facts = append(s.Facts, sf)
+	}
+
+	sort.Slice(s.Facts, func(i, j int) bool {
+		return s.Facts[i].Id < s.Facts[j].Id
+	})
+	return s, nil
+}
+
+// SaveSnapshot writes a snapshot of the collected facts to the given file.
+func (m *manager) SaveSnapshot(path string) error {
+	s, err := m.NewSnapshot()
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"path":  path,
+		"facts": len(s.Facts),
+	}).Info("saving facts snapshot")
+	return os.WriteFile(path, content, 0644)
+}
+
+// LoadSnapshot replaces the facts with the ones restored from the given
+// snapshot; they are then considered collected.
+func (m *manager) LoadSnapshot(s *Snapshot) error {
+	if s.Version != SnapshotVersion {
+		return &ErrSnapshotVersion{Version: s.Version}
+	}
+
+	plugins := map[string]Facter{}
+	for _, sf := range s.Facts {
+		d, err := data.Decode(sf.Format, sf.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode data for fact '%s': %w", sf.Id, err)
+		}
+
+		f := &Replayed{plugin: sf.Plugin}
+		f.Id = sf.Id
+		f.Format = sf.Format
+		f.Publish = sf.Publish
+		f.SetData(d)
+		f.SetLocations(sf.Locations)
+		for _, e := range sf.Errors {
+			f.AddErrors(errors.New(e))
+		}
+		plugins[sf.Id] = f
+	}
+
+	m.SetPlugins(plugins)
+	m.ResetCollected()
+	for id := range plugins {
+		m.markCollected(id)
+	}
+	log.WithField("facts", len(plugins)).Info("loaded facts snapshot")
+	return nil
+}
+
+// LoadSnapshotFile reads a snapshot from the given file and loads it.
+func (m *manager) LoadSnapshotFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	s := &Snapshot{}
+	if err := json.Unmarshal(content, s); err != nil {
+		return fmt.Errorf("failed to parse snapshot '%s': %w", path, err)
+	}
+	return m.LoadSnapshot(s)
+}
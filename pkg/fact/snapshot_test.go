@@ -0,0 +1,99 @@
+package fact_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/data"
+	. "github.com/salsadigitalauorg/shipshape/pkg/fact"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact/testdata"
+)
+
+func TestSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	reset := func() {
+		Manager().ResetPlugins()
+		Manager().ResetErrors()
+		Manager().ResetCollected()
+	}
+	reset()
+	defer reset()
+
+	failing := testdata.New("failing", data.FormatNil, nil)
+	failing.AddErrors(errors.New("failed to collect"))
+	failing.Publish = true
+	files := testdata.New("files", data.FormatMapBytes,
+		map[string][]byte{"foo.yml": []byte("foo: bar")})
+	files.SetLocations(map[string]breach.Location{"foo.yml": {File: "foo.yml"}})
+	files.Publish = true
+	Manager().SetPlugins(map[string]Facter{
+		"files":   files,
+		"list":    testdata.New("list", data.FormatListString, []string{"a", "b"}),
+		"failing": failing,
+	})
+	Manager().CollectAllFacts(context.Background())
+	assert.Equal([]Facter{files}, Manager().GetPublished())
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	assert.NoError(Manager().SaveSnapshot(path))
+
+	reset()
+	assert.NoError(Manager().LoadSnapshotFile(path))
+
+	filesReplayed := Manager().FindPlugin("files")
+	assert.Equal("testdata:testfacter", filesReplayed.GetName())
+	assert.Equal(data.FormatMapBytes, filesReplayed.GetFormat())
+	assert.Equal(map[string][]byte{"foo.yml": []byte("foo: bar")}, filesReplayed.GetData())
+	assert.Equal(&breach.Location{File: "foo.yml"}, filesReplayed.GetLocation("foo.yml"))
+	assert.True(filesReplayed.ShouldPublish())
+	assert.Equal([]Facter{filesReplayed}, Manager().GetPublished())
+
+	list := Manager().FindPlugin("list")
+	assert.Equal(data.FormatListString, list.GetFormat())
+	assert.Equal([]string{"a", "b"}, list.GetData())
+	assert.False(list.ShouldPublish())
+
+	failingReplayed := Manager().FindPlugin("failing")
+	assert.Nil(failingReplayed.GetData())
+	assert.EqualError(failingReplayed.GetErrors()[0], "failed to collect")
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	defer Manager().ResetPlugins()
+
+	t.Run("unsupportedVersion", func(t *testing.T) {
+		err := Manager().LoadSnapshot(&Snapshot{Version: 99})
+		assert.EqualError(t, err, "unsupported snapshot version 99, expected 1")
+	})
+
+	t.Run("unknownFormat", func(t *testing.T) {
+		err := Manager().LoadSnapshot(&Snapshot{
+			Version: SnapshotVersion,
+			Facts: []SnapshotFact{
+				{Id: "foo", Plugin: "bar", Format: "baz", Data: []byte(`"qux"`)},
+			},
+		})
+		assert.EqualError(t, err,
+			"failed to decode data for fact 'foo': no decoder found for data format 'baz'")
+	})
+
+	t.Run("invalidFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		assert.NoError(t, os.WriteFile(path, []byte("foo"), 0644))
+		err := Manager().LoadSnapshotFile(path)
+		assert.ErrorContains(t, err, "failed to parse snapshot")
+	})
+}
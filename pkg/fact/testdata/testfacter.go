@@ -1,13 +1,15 @@
 package testdata
 
 import (
+	"context"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
 	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
 )
 
 type TestFacter struct {
-	fact.BaseFact
+	fact.BaseFact `yaml:",inline"`
 
 	// Plugin fields.
 	TestInputDataFormat data.DataFormat
@@ -36,7 +38,7 @@ func (p *TestFacter) GetName() string {
 	return "testdata:testfacter"
 }
 
-func (p *TestFacter) Collect() {
+func (p *TestFacter) Collect(ctx context.Context) {
 	p.Format = p.TestInputDataFormat
 	p.SetData(p.TestInputData)
 }
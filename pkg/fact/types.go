@@ -1,6 +1,10 @@
 package fact
 
 import (
+	"context"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/connection"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
@@ -13,6 +17,10 @@ type Facter interface {
 	// Data methods
 	GetData() interface{}
 	GetFormat() data.DataFormat
+	GetTimeout() time.Duration
+	GetLocation(key string) *breach.Location
+	GetLocations() map[string]breach.Location
+	SetLocations(map[string]breach.Location)
 
 	// Connection methods
 	GetConnectionName() string
@@ -31,5 +39,8 @@ type Facter interface {
 	SetAdditionalInputs([]Facter)
 
 	// Collection
-	Collect()
+	Collect(ctx context.Context)
+
+	// Publishing
+	ShouldPublish() bool
 }
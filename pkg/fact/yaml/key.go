@@ -1,11 +1,13 @@
 package yaml
 
 import (
+	"context"
 	"errors"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/env"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
@@ -59,7 +61,7 @@ func (p *Key) SupportedInputFormats() (plugin.SupportLevel, []data.DataFormat) {
 	}
 }
 
-func (p *Key) Collect() {
+func (p *Key) Collect(ctx context.Context) {
 	var lookup *YamlLookup
 	var lookupMap *MapYamlLookup
 	var nestedLookupMap map[string]*MapYamlLookup
@@ -165,6 +167,23 @@ func (p *Key) Collect() {
 		return
 	}
 
+	if lookup != nil {
+		p.SetLocations(lookup.Locations(p.inputFile("")))
+	} else if lookupMap != nil {
+		if p.GetInput().GetFormat() == data.FormatMapBytes {
+			p.SetLocations(lookupMap.Locations(p.inputFile))
+		} else {
+			file := p.inputFile("")
+			p.SetLocations(lookupMap.Locations(func(string) string { return file }))
+		}
+	} else {
+		locations := map[string]breach.Location{}
+		for f := range nestedLookupMap {
+			locations[f] = breach.Location{File: p.inputFile(f)}
+		}
+		p.SetLocations(locations)
+	}
+
 	if p.NodesOnly {
 		if lookup != nil {
 			p.Format = FormatYamlNodes
@@ -236,3 +255,12 @@ func (p *Key) Collect() {
 		p.SetData(res)
 	}
 }
+
+// inputFile returns the file the input data for the given key was read from,
+// defaulting to the key itself.
+func (p *Key) inputFile(key string) string {
+	if loc := p.GetInput().GetLocation(key); loc != nil {
+		return loc.File
+	}
+	return key
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
 	. "github.com/salsadigitalauorg/shipshape/pkg/fact/yaml"
@@ -144,9 +145,39 @@ func TestKeyCollect(t *testing.T) {
 				DataFormat: data.FormatRaw, Data: []byte(`foo:
   bar: baz
   zoo: bar
-`)},
+`),
+				Locations: map[string]breach.Location{"": {File: "core.extension.yml"}},
+			},
 			ExpectedFormat: data.FormatListString,
 			ExpectedData:   []string{"bar", "zoo"},
+			ExpectedLocations: map[string]breach.Location{
+				"":    {File: "core.extension.yml", Line: 2, EndLine: 3, Column: 3},
+				"bar": {File: "core.extension.yml", Line: 2, Column: 3},
+				"zoo": {File: "core.extension.yml", Line: 3, Column: 3},
+			},
+		},
+		{
+			Name: "inputFormat/Raw/list/Locations",
+			FactFn: func() fact.Facter {
+				f := New("base-images")
+				f.SetInputName("test-input")
+				f.Path = "foo"
+				return f
+			},
+			TestInput: internal.FactInputTest{
+				DataFormat: data.FormatRaw, Data: []byte(`foo:
+  - bar
+  - baz
+`),
+				Locations: map[string]breach.Location{"": {File: "docker-compose.yml"}},
+			},
+			ExpectedFormat: data.FormatListString,
+			ExpectedData:   []string{"bar", "baz"},
+			ExpectedLocations: map[string]breach.Location{
+				"":    {File: "docker-compose.yml", Line: 2, EndLine: 3, Column: 3},
+				"bar": {File: "docker-compose.yml", Line: 2, Column: 5},
+				"baz": {File: "docker-compose.yml", Line: 3, Column: 5},
+			},
 		},
 
 		// Map of Raw data (data.FormatMapBytes) format cases.
@@ -190,10 +221,14 @@ func TestKeyCollect(t *testing.T) {
 			},
 			TestInput: internal.FactInputTest{
 				DataFormat: data.FormatMapBytes,
-				Data:       map[string][]byte{"file1": []byte("foo: bar")},
+				Data:       map[string][]byte{"/app/file1": []byte("bar: baz\nfoo: bar")},
+				Locations:  map[string]breach.Location{"/app/file1": {File: "file1"}},
 			},
 			ExpectedFormat: data.FormatMapString,
-			ExpectedData:   map[string]any{"file1": "bar"},
+			ExpectedData:   map[string]any{"/app/file1": "bar"},
+			ExpectedLocations: map[string]breach.Location{
+				"/app/file1": {File: "file1", Line: 2, Column: 6},
+			},
 		},
 		{
 			Name: "inputFormat/MapBytes/list",
@@ -265,9 +300,14 @@ func TestKeyCollect(t *testing.T) {
 					yaml.Unmarshal([]byte("bar: \n  baz: zap"), &node2)
 					return []*yaml.Node{node1.Content[0], node2.Content[0]}
 				},
+				Locations: map[string]breach.Location{"": {File: "docker-compose.yml"}},
 			},
 			ExpectedFormat: data.FormatMapString,
 			ExpectedData:   map[string]any{"bar": "zap", "foo": "zoom"},
+			ExpectedLocations: map[string]breach.Location{
+				"bar": {File: "docker-compose.yml", Line: 2, Column: 8},
+				"foo": {File: "docker-compose.yml", Line: 2, Column: 8},
+			},
 		},
 		{
 			Name: "inputFormat/YamlNodes/list",
@@ -13,6 +13,11 @@ const (
 	FormatMapYamlNodes data.DataFormat = "map-yaml-nodes"
 )
 
+func init() {
+	data.Decoders[FormatYamlNodes] = data.DecodeAs[[]*yaml.Node]
+	data.Decoders[FormatMapYamlNodes] = data.DecodeAs[map[string][]*yaml.Node]
+}
+
 type YamlLookup struct {
 	Nodes  []*yaml.Node
 	Path   string
@@ -0,0 +1,35 @@
+package yaml_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/data"
+	. "github.com/salsadigitalauorg/shipshape/pkg/fact/yaml"
+)
+
+func TestDecoders(t *testing.T) {
+	assert := assert.New(t)
+
+	n := yaml.Node{}
+	assert.NoError(yaml.Unmarshal([]byte("foo:\n  bar: baz\n"), &n))
+	nodes := n.Content[0].Content
+
+	encoded, err := data.Encode(nodes)
+	assert.NoError(err)
+	decoded, err := data.Decode(FormatYamlNodes, encoded)
+	assert.NoError(err)
+	assert.Equal(nodes, decoded)
+	decodedNodes := decoded.([]*yaml.Node)
+	assert.Equal(2, decodedNodes[1].Content[0].Line)
+	assert.Equal(3, decodedNodes[1].Content[0].Column)
+
+	mapNodes := map[string][]*yaml.Node{"foo.yml": nodes}
+	encoded, err = data.Encode(mapNodes)
+	assert.NoError(err)
+	decoded, err = data.Decode(FormatMapYamlNodes, encoded)
+	assert.NoError(err)
+	assert.Equal(mapNodes, decoded)
+}
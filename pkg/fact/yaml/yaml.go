@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/env"
 	"github.com/salsadigitalauorg/shipshape/pkg/utils"
@@ -140,6 +141,62 @@ func (y *YamlLookup) ProcessNodes(envMap map[string]string) {
 	}
 }
 
+// Locations returns the location of the found nodes in the given file: the
+// empty key for the whole data, along with the values of a list or the keys
+// of a map.
+func (y *YamlLookup) Locations(file string) map[string]breach.Location {
+	n := y.Nodes[0]
+	locations := map[string]breach.Location{"": NodeLocation(file, n)}
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			if item.Kind == yaml.ScalarNode {
+				locations[item.Value] = NodeLocation(file, item)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			loc := NodeLocation(file, n.Content[i])
+			if end := nodeEndLine(n.Content[i+1]); end > loc.Line {
+				loc.EndLine = end
+			}
+			locations[n.Content[i].Value] = loc
+		}
+	}
+	return locations
+}
+
+// Locations returns the location of the found nodes for each key of the
+// map, the file for each key being provided by fileOf.
+func (m *MapYamlLookup) Locations(fileOf func(key string) string) map[string]breach.Location {
+	locations := map[string]breach.Location{}
+	for k, lookup := range m.LookupMap {
+		locations[k] = NodeLocation(fileOf(k), lookup.Nodes[0])
+	}
+	return locations
+}
+
+// NodeLocation returns the location of a node in a file, spanning all the
+// lines of its content.
+func NodeLocation(file string, n *yaml.Node) breach.Location {
+	loc := breach.Location{File: file, Line: n.Line, Column: n.Column}
+	if end := nodeEndLine(n); end > n.Line {
+		loc.EndLine = end
+	}
+	return loc
+}
+
+// nodeEndLine returns the last line of a node's content.
+func nodeEndLine(n *yaml.Node) int {
+	end := n.Line
+	for _, c := range n.Content {
+		if l := nodeEndLine(c); l > end {
+			end = l
+		}
+	}
+	return end
+}
+
 func (m *MapYamlLookup) GetMapNodes() map[string][]*yaml.Node {
 	result := map[string][]*yaml.Node{}
 	for f, lookup := range m.LookupMap {
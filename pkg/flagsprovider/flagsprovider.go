@@ -10,7 +10,18 @@ type FlagsProvider interface {
 var Registry = map[string]func() FlagsProvider{}
 var FlagProviders = map[string]FlagsProvider{}
 
+// RegisterAll creates the providers which haven't been yet; it can be called
+// multiple times, since commands adding flags may be initialised in any order.
+func RegisterAll() {
+	for name, fp := range Registry {
+		if _, ok := FlagProviders[name]; !ok {
+			FlagProviders[name] = fp()
+		}
+	}
+}
+
 func AddFlagsAll(c *cobra.Command) {
+	RegisterAll()
 	for _, p := range FlagProviders {
 		p.AddFlags(c)
 	}
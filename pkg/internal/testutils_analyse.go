@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -28,7 +29,7 @@ func TestAnalyse(t *testing.T, at AnalyseTest) {
 	defer logrus.SetOutput(currLogOut)
 	logrus.SetOutput(io.Discard)
 
-	at.Input.Collect()
+	at.Input.Collect(context.Background())
 	at.Analyser.SetInput(at.Input)
 	at.Analyser.Analyse()
 
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
@@ -41,3 +42,12 @@ func ShellCommanderMaker(out *string, err error, generatedCommand *string) func(
 		}
 	}
 }
+
+// ShellCommanderContextMaker is the context-aware equivalent of
+// ShellCommanderMaker.
+func ShellCommanderContextMaker(out *string, err error, generatedCommand *string) func(ctx context.Context, name string, arg ...string) command.IShellCommand {
+	maker := ShellCommanderMaker(out, err, generatedCommand)
+	return func(ctx context.Context, name string, arg ...string) command.IShellCommand {
+		return maker(name, arg...)
+	}
+}
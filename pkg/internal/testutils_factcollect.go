@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"io"
 	"reflect"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/data"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact"
 	"github.com/salsadigitalauorg/shipshape/pkg/fact/testdata"
@@ -17,6 +19,7 @@ type FactInputTest struct {
 	DataFormat data.DataFormat
 	Data       any
 	DataFn     func() any
+	Locations  map[string]breach.Location
 }
 
 type FactCollectTest struct {
@@ -33,6 +36,8 @@ type FactCollectTest struct {
 	ExpectedErrors []error
 	ExpectedFormat data.DataFormat
 	ExpectedData   interface{}
+	// ExpectedLocations is only verified when set.
+	ExpectedLocations map[string]breach.Location
 }
 
 // TestFactCollect is used to run test scenarios in test tables.
@@ -65,7 +70,8 @@ func TestFactCollect(t *testing.T, fct FactCollectTest) {
 		testP := p.(*testdata.TestFacter)
 		testP.TestInputDataFormat = fct.TestInput.DataFormat
 		testP.TestInputData = fct.TestInput.Data
-		testP.Collect()
+		testP.Collect(context.Background())
+		testP.SetLocations(fct.TestInput.Locations)
 	}
 
 	err := fact.ValidateInput(fct.Facter)
@@ -87,7 +93,7 @@ func TestFactCollect(t *testing.T, fct FactCollectTest) {
 			testP := p.(*testdata.TestFacter)
 			testP.TestInputDataFormat = testInput.DataFormat
 			testP.TestInputData = testInput.Data
-			testP.Collect()
+			testP.Collect(context.Background())
 		}
 
 		errs := fact.LoadAdditionalInputs(fct.Facter)
@@ -100,9 +106,12 @@ func TestFactCollect(t *testing.T, fct FactCollectTest) {
 	}
 
 	// Collect data.
-	fct.Facter.Collect()
+	fct.Facter.Collect(context.Background())
 	assert.ElementsMatch(fct.ExpectedErrors, fct.Facter.GetErrors())
 	assert.Equal(fct.ExpectedFormat, fct.Facter.GetFormat())
+	if fct.ExpectedLocations != nil {
+		assert.Equal(fct.ExpectedLocations, fct.Facter.GetLocations())
+	}
 
 	if fct.ExpectedData == nil {
 		return
@@ -21,8 +21,12 @@ func MockLagoonServer() *httptest.Server {
 			fmt.Fprintf(w, "{\"data\":{\"environmentByKubernetesNamespaceName\":{\"id\": 50}}}")
 		} else if strings.Contains(string(reqBody), "deleteProblemsFromSource") { // Response for the deletion.
 			fmt.Fprintf(w, "{\"data\":{\"deleteProblemsFromSource\":\"success\"}}")
+		} else if strings.Contains(string(reqBody), "deleteFactsFromSource") {
+			fmt.Fprintf(w, "{\"data\":{\"deleteFactsFromSource\":\"success\"}}")
 		} else if strings.Contains(string(reqBody), "AddFactsByNameInput") { // Response for the add.
 			fmt.Fprintf(w, "{}")
+		} else if strings.Contains(string(reqBody), "AddProblemInput") {
+			fmt.Fprintf(w, "{\"data\":{\"addProblem\":{\"id\": 1}}}")
 		} else {
 			panic(string(reqBody))
 		}
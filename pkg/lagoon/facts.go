@@ -0,0 +1,134 @@
+package lagoon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/fact"
+)
+
+// AddFactsByNameInput is the input of the addFactsByName mutation, which
+// identifies the environment by its project and environment names.
+type AddFactsByNameInput struct {
+	Project     string `json:"project"`
+	Environment string `json:"environment"`
+	Facts       []Fact `json:"facts"`
+}
+
+// FactsFromCollected converts the collected facts marked to be published to
+// Lagoon facts.
+func (p *Lagoon) FactsFromCollected(facts []fact.Facter) ([]Fact, error) {
+	lFacts := []Fact{}
+	for _, f := range facts {
+		value, err := FactValue(f.GetData())
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert fact '%s': %w", f.GetId(), err)
+		}
+		lFacts = append(lFacts, Fact{
+			Name:        f.GetId(),
+			Value:       value,
+			Source:      p.Source,
+			Description: fmt.Sprintf("%s fact collected by Shipshape", f.GetName()),
+			Category:    "Shipshape",
+		})
+	}
+	return lFacts, nil
+}
+
+// FactValue converts a fact's data to a string; data other than strings is
+// converted to json.
+func FactValue(data interface{}) (string, error) {
+	if s, ok := data.(string); ok {
+		return s, nil
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// PushFacts replaces the environment's facts from the source with the given
+// ones. The facts are validated before the existing ones are deleted, since
+// the environment is left without facts from the source if adding them fails.
+func (p *Lagoon) PushFacts(facts []Fact) error {
+	log.WithFields(log.Fields{
+		"project":     p.Project,
+		"environment": p.Environment,
+		"source":      p.Source,
+		"facts":       len(facts),
+	}).Debug("pushing facts to Lagoon")
+
+	if err := ValidateFacts(facts); err != nil {
+		return err
+	}
+	if err := p.DeleteFacts(); err != nil {
+		return err
+	}
+	if len(facts) == 0 {
+		return nil
+	}
+
+	var m struct {
+		AddFactsByName []struct {
+			Id int
+		} `graphql:"addFactsByName(input: $input)"`
+	}
+	variables := map[string]interface{}{
+		"input": AddFactsByNameInput{
+			Project:     p.Project,
+			Environment: p.Environment,
+			Facts:       facts,
+		},
+	}
+	if err := Client.Mutate(context.Background(), &m, variables); err != nil {
+		return errors.Join(fmt.Errorf(
+			"facts from source '%s' were deleted but could not be added again", p.Source), err)
+	}
+	return nil
+}
+
+// ValidateFacts ensures the facts can be added to Lagoon: each one requires a
+// name, unique within the facts, and a source.
+func ValidateFacts(facts []Fact) error {
+	names := map[string]bool{}
+	for _, f := range facts {
+		if f.Name == "" {
+			return fmt.Errorf("fact with value '%s' has no name", f.Value)
+		}
+		if f.Source == "" {
+			return fmt.Errorf("fact '%s' has no source", f.Name)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("duplicate fact '%s'", f.Name)
+		}
+		names[f.Name] = true
+	}
+	return nil
+}
+
+// DeleteFacts deletes the environment's facts from the source.
+func (p *Lagoon) DeleteFacts() error {
+	log.WithFields(log.Fields{
+		"project":     p.Project,
+		"environment": p.Environment,
+		"source":      p.Source,
+	}).Debug("deleting facts from Lagoon")
+
+	envId, err := GetEnvironmentId(p.Project, p.Environment)
+	if err != nil {
+		return err
+	}
+	var m struct {
+		DeleteFactsFromSource string `graphql:"deleteFactsFromSource(input: {environment: $envId, source: $sourceName})"`
+	}
+	variables := map[string]interface{}{
+		"envId":      envId,
+		"sourceName": p.Source,
+	}
+	return Client.Mutate(context.Background(), &m, variables)
+}
@@ -0,0 +1,144 @@
+package lagoon_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/data"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact/testdata"
+	"github.com/salsadigitalauorg/shipshape/pkg/internal"
+	"github.com/salsadigitalauorg/shipshape/pkg/lagoon"
+)
+
+func TestFactValue(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := lagoon.FactValue("10.2.1")
+	assert.NoError(err)
+	assert.Equal("10.2.1", v)
+
+	v, err = lagoon.FactValue([]string{"views", "node"})
+	assert.NoError(err)
+	assert.Equal(`["views","node"]`, v)
+
+	v, err = lagoon.FactValue(map[string]string{"php": "8.3"})
+	assert.NoError(err)
+	assert.Equal(`{"php":"8.3"}`, v)
+}
+
+func TestFactsFromCollected(t *testing.T) {
+	assert := assert.New(t)
+
+	version := testdata.New("drupal-version", data.FormatRaw, "10.2.1")
+	version.Collect(context.Background())
+	modules := testdata.New("modules", data.FormatListString, []string{"views", "node"})
+	modules.Collect(context.Background())
+
+	o := lagoon.Lagoon{Source: "Shipshape"}
+	facts, err := o.FactsFromCollected([]fact.Facter{version, modules})
+	assert.NoError(err)
+	assert.Equal([]lagoon.Fact{
+		{
+			Name:        "drupal-version",
+			Value:       "10.2.1",
+			Source:      "Shipshape",
+			Description: "testdata:testfacter fact collected by Shipshape",
+			Category:    "Shipshape",
+		},
+		{
+			Name:        "modules",
+			Value:       `["views","node"]`,
+			Source:      "Shipshape",
+			Description: "testdata:testfacter fact collected by Shipshape",
+			Category:    "Shipshape",
+		},
+	}, facts)
+}
+
+func TestPushFacts(t *testing.T) {
+	assert := assert.New(t)
+
+	svr := internal.MockLagoonServer()
+	lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+	origOutput := logrus.StandardLogger().Out
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	defer func() {
+		svr.Close()
+		internal.MockLagoonReset()
+		lagoon.Client = nil
+		logrus.SetOutput(origOutput)
+	}()
+
+	o := lagoon.Lagoon{Project: "foo", Environment: "bar", Source: "Shipshape"}
+	err := o.PushFacts([]lagoon.Fact{{
+		Name:        "drupal-version",
+		Value:       "10.2.1",
+		Source:      "Shipshape",
+		Description: "file:read fact collected by Shipshape",
+		Category:    "Shipshape",
+	}})
+	assert.NoError(err)
+	assert.Equal(3, internal.MockLagoonNumCalls)
+	assert.Equal("{\"query\":\"query ($ns:String!){"+
+		"environmentByKubernetesNamespaceName(kubernetesNamespaceName: $ns)"+
+		"{id}}\",\"variables\":{\"ns\":\"foo-bar\"}}\n", internal.MockLagoonRequestBodies[0])
+	assert.Equal("{\"query\":\"mutation ($envId:Int!$sourceName:String!)"+
+		"{deleteFactsFromSource(input: {environment: $envId, source: $sourceName})}\","+
+		"\"variables\":{\"envId\":50,\"sourceName\":\"Shipshape\"}}\n",
+		internal.MockLagoonRequestBodies[1])
+	assert.Equal("{\"query\":\"mutation ($input:AddFactsByNameInput!)"+
+		"{addFactsByName(input: $input){id}}\",\"variables\":{\"input\":{"+
+		"\"project\":\"foo\",\"environment\":\"bar\",\"facts\":[{\"name\":\"drupal-version\","+
+		"\"value\":\"10.2.1\",\"source\":\"Shipshape\",\"description\":"+
+		"\"file:read fact collected by Shipshape\",\"category\":\"Shipshape\"}]}}}\n",
+		internal.MockLagoonRequestBodies[2])
+
+	t.Run("noFact", func(t *testing.T) {
+		internal.MockLagoonReset()
+		assert.NoError(o.PushFacts([]lagoon.Fact{}))
+		assert.Equal(2, internal.MockLagoonNumCalls)
+	})
+
+	t.Run("invalidFacts", func(t *testing.T) {
+		internal.MockLagoonReset()
+		err := o.PushFacts([]lagoon.Fact{
+			{Name: "php-version", Value: "8.3", Source: "Shipshape"},
+			{Name: "php-version", Value: "8.2", Source: "Shipshape"},
+		})
+		assert.EqualError(err, "duplicate fact 'php-version'")
+		// Nothing is deleted.
+		assert.Equal(0, internal.MockLagoonNumCalls)
+	})
+
+	t.Run("addFailure", func(t *testing.T) {
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqBody, _ := io.ReadAll(r.Body)
+			switch {
+			case strings.Contains(string(reqBody), "environmentByKubernetesNamespaceName"):
+				fmt.Fprint(w, `{"data":{"environmentByKubernetesNamespaceName":{"id": 50}}}`)
+			case strings.Contains(string(reqBody), "deleteFactsFromSource"):
+				fmt.Fprint(w, `{"data":{"deleteFactsFromSource":"success"}}`)
+			default:
+				fmt.Fprint(w, `{"errors":[{"message":"invalid fact"}]}`)
+			}
+		}))
+		defer svr.Close()
+		lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+
+		err := o.PushFacts([]lagoon.Fact{{Name: "php-version", Value: "8.3", Source: "Shipshape"}})
+		assert.ErrorContains(err, "facts from source 'Shipshape' were deleted but could not be added again")
+		assert.ErrorContains(err, "invalid fact")
+	})
+}
@@ -31,9 +31,21 @@ to API (env: LAGOON_API_TOKEN)`)
 		"http://lagoon-remote-insights-remote.lagoon.svc/problems",
 		"Insights Remote Problems endpoint\n")
 
+	c.Flags().BoolVar(&p.PublishFacts, "lagoon-push-facts", false,
+		"Push the facts marked with 'publish: true' to Lagoon")
+
 	c.Flags().StringVar(&p.Source, "lagoon-source", "Shipshape",
 		"Source to use for Problems pushed to Lagoon")
 
+	c.Flags().StringVar(&p.Service, "lagoon-service", "",
+		"Service to use for Problems pushed to Lagoon, e.g, 'cli'")
+
+	c.Flags().StringVar(&p.ProblemsPer, "lagoon-problems-per", ProblemsPerResult,
+		"Push a problem per 'result' or per 'breach' to Lagoon")
+
+	c.Flags().IntVar(&p.Retries, "lagoon-retries", 3,
+		"Number of times to retry pushing problems to Insights Remote")
+
 	c.Flags().StringVar(&p.Project, "lagoon-project", "",
 		"The Lagoon project name (env: LAGOON_PROJECT)")
 
@@ -53,7 +65,7 @@ func (p *Lagoon) EnvironmentOverrides() {
 	}
 
 	// simple check to ensure we have everything we need to write to the API if required.
-	if p.PushProblemsToInsightsRemote {
+	if p.PushProblemsToInsightsRemote || p.PublishFacts {
 		if p.ApiBaseUrl == "" {
 			log.Fatal("lagoon api base url not provided")
 		}
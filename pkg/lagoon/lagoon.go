@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -41,6 +42,9 @@ type Problem struct {
 	Links             string                `json:"links,omitempty"`
 }
 
+// AddProblemInput is the input of the addProblem mutation.
+type AddProblemInput Problem
+
 var Client *graphql.Client
 
 func InitClient(apiBaseUrl, apiToken string) {
@@ -88,6 +92,25 @@ func GetBearerTokenFromDisk(tokenLocation string) (string, error) {
 	return strings.Trim(string(b), "\n"), nil
 }
 
+// ErrInsightsRemoteStatus is returned when Insights Remote responds to the
+// problems with a status other than 200.
+type ErrInsightsRemoteStatus struct {
+	Endpoint   string
+	StatusCode int
+	Body       string
+}
+
+func (e *ErrInsightsRemoteStatus) Error() string {
+	return fmt.Sprintf("there was an error sending the problems to '%s' : %s",
+		e.Endpoint, e.Body)
+}
+
+// Retryable determines whether the request can be retried, i.e, it failed
+// because of a server error or rate limiting.
+func (e *ErrInsightsRemoteStatus) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
 func ProblemsToInsightsRemote(problems []Problem, serviceEndpoint string, bearerToken string) error {
 	bodyString, err := json.Marshal(problems)
 	if err != nil {
@@ -107,8 +130,15 @@ func ProblemsToInsightsRemote(problems []Problem, serviceEndpoint string, bearer
 		return err
 	}
 
+	defer response.Body.Close()
+
 	if response.StatusCode != 200 {
-		return fmt.Errorf("there was an error sending the problems to '%s' : %s", serviceEndpoint, response.Body)
+		respBody, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return &ErrInsightsRemoteStatus{
+			Endpoint:   serviceEndpoint,
+			StatusCode: response.StatusCode,
+			Body:       strings.TrimSpace(string(respBody)),
+		}
 	}
 	return nil
 }
@@ -5,13 +5,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact"
 	"github.com/salsadigitalauorg/shipshape/pkg/output"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
 )
 
 // Lagoon is the output plugin for pushing problems to Lagoon.
@@ -29,35 +36,107 @@ type Lagoon struct {
 	PushProblemsToInsightsRemote bool   `yaml:"push-problems-to-insights"`
 	InsightsRemoteEndpoint       string `yaml:"insights-remote-endpoint"`
 
+	// PublishFacts pushes the collected facts marked with `publish: true`
+	// to the Lagoon API as environment facts.
+	PublishFacts bool `yaml:"push-facts"`
+
 	// Source can be specified when pushing Problems to Lagoon.
 	// Default is "Shipshape".
 	Source string `yaml:"source"`
 
+	// Service is the name of the service the problems are reported for,
+	// e.g, "cli".
+	Service string `yaml:"service"`
+
+	// ProblemsPer determines what a problem is pushed for: "result"
+	// (default), with all its breaches as data, or "breach".
+	ProblemsPer string `yaml:"problems-per"`
+
+	// Retries is the number of times pushing problems to Insights Remote
+	// is retried if it fails.
+	Retries int `yaml:"retries"`
+	// Backoff is the delay before the first retry, doubled for each of the
+	// following ones, e.g, "1s".
+	Backoff string `yaml:"backoff"`
+
 	Project     string `yaml:"project"`
 	Environment string `yaml:"environment"`
 }
 
+const (
+	// ProblemsPerResult pushes a problem for each result with breaches.
+	ProblemsPerResult = "result"
+	// ProblemsPerBreach pushes a problem for each active breach.
+	ProblemsPerBreach = "breach"
+)
+
+// InsightsTokenLocation is where the insights token is read from; problems
+// are pushed to the Lagoon API if it is missing.
+var InsightsTokenLocation = DefaultLagoonInsightsTokenLocation
+
 var l = &Lagoon{Source: "Shipshape"}
 
 func init() {
 	output.Outputters["lagoon"] = l
+	// New instances start from the flags' values.
+	output.Factories["lagoon"] = func() output.Outputter {
+		o := *l
+		return &o
+	}
 }
 
 func (p *Lagoon) Output(rl *result.ResultList) ([]byte, error) {
+	buf := bytes.Buffer{}
+	errs := []error{}
+	// Facts and problems are pushed independently, so that failing to push
+	// one doesn't prevent the other.
+	if p.PublishFacts {
+		if out, err := p.outputFacts(); err != nil {
+			errs = append(errs, err)
+		} else {
+			buf.Write(out)
+		}
+	}
+
 	if !p.PushProblemsToInsightsRemote {
 		log.Debug("skipping pushing problems to Lagoon")
-		return nil, nil
+	} else if out, err := p.outputProblems(rl); err != nil {
+		errs = append(errs, err)
+	} else {
+		buf.Write(out)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return buf.Bytes(), nil
+}
+
+// outputFacts pushes the published facts to Lagoon.
+func (p *Lagoon) outputFacts() ([]byte, error) {
+	facts, err := p.FactsFromCollected(fact.Manager().GetPublished())
+	if err != nil {
+		return nil, err
+	}
+	InitClient(p.ApiBaseUrl, p.ApiToken)
+	if err := p.PushFacts(facts); err != nil {
+		return nil, err
 	}
+	return []byte(fmt.Sprintf("successfully pushed %d fact(s) to Lagoon\n", len(facts))), nil
+}
+
+// outputProblems pushes the breaches to Lagoon as problems.
+func (p *Lagoon) outputProblems(rl *result.ResultList) ([]byte, error) {
 
 	log.WithFields(log.Fields{
 		"insights-remote-endpoint": p.InsightsRemoteEndpoint,
 		"project":                  p.Project,
 		"environment":              p.Environment,
 		"source":                   p.Source,
+		"service":                  p.Service,
 	}).Debug("pushing problems to Lagoon")
 	buf := bytes.Buffer{}
 	bufW := bufio.NewWriter(&buf)
-	problems := []Problem{}
 
 	if rl.TotalBreaches == 0 {
 		log.WithFields(log.Fields{
@@ -76,48 +155,148 @@ func (p *Lagoon) Output(rl *result.ResultList) ([]byte, error) {
 		return buf.Bytes(), nil
 	}
 
-	for _, r := range rl.Results {
-		if len(r.Breaches) == 0 {
-			continue
-		}
-
-		// let's marshal the breaches, they can be attached to the problem in the data field
-		breachMapJson, err := json.Marshal(r.Breaches)
-		if err != nil {
-			log.WithError(err).Fatal("Unable to marshal breach information")
-		}
-
-		problems = append(problems, Problem{
-			Identifier:        r.Name,
-			Version:           "1",
-			FixedVersion:      "",
-			Source:            p.Source,
-			Service:           "",
-			Data:              string(breachMapJson),
-			Severity:          SeverityTranslation(config.Severity(r.Severity)),
-			SeverityScore:     0,
-			AssociatedPackage: "",
-			Description:       "",
-			Links:             "",
-		})
+	problems, err := p.Problems(rl)
+	if err != nil {
+		return nil, err
 	}
 
 	InitClient(p.ApiBaseUrl, p.ApiToken)
 	// first, let's try doing this via in-cluster functionality
-	bearerToken, err := GetBearerTokenFromDisk(DefaultLagoonInsightsTokenLocation)
+	bearerToken, err := GetBearerTokenFromDisk(InsightsTokenLocation)
 	if err == nil { // we have a token, and so we can proceed via the internal service call
-		err = ProblemsToInsightsRemote(problems, p.InsightsRemoteEndpoint, bearerToken)
-		if err != nil {
+		if err := p.pushToInsightsRemote(problems, bearerToken); err != nil {
 			return nil, err
 		}
+		fmt.Fprintln(bufW, "successfully pushed problems to Lagoon Remote")
 	} else {
-		return nil, err
+		log.WithError(err).Info("no insights token, pushing problems to the Lagoon API")
+		if err := p.AddProblems(problems); err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(bufW, "successfully pushed problems to Lagoon")
 	}
-	fmt.Fprintln(bufW, "successfully pushed problems to Lagoon Remote")
 	bufW.Flush()
 	return buf.Bytes(), nil
 }
 
+// Problems converts the results to problems: one per result with breaches,
+// or one per active breach.
+func (p *Lagoon) Problems(rl *result.ResultList) ([]Problem, error) {
+	if p.ProblemsPer != "" && p.ProblemsPer != ProblemsPerResult && p.ProblemsPer != ProblemsPerBreach {
+		return nil, fmt.Errorf("unsupported lagoon problems-per '%s', expected one of: %s, %s",
+			p.ProblemsPer, ProblemsPerResult, ProblemsPerBreach)
+	}
+
+	problems := []Problem{}
+	for _, r := range rl.Results {
+		description := r.Description
+		if description == "" {
+			description = r.Name
+		}
+
+		if p.ProblemsPer != ProblemsPerBreach {
+			breaches := r.ActiveBreaches()
+			if len(breaches) == 0 {
+				continue
+			}
+
+			// let's marshal the breaches, they can be attached to the problem in the data field
+			breachMapJson, err := json.Marshal(breaches)
+			if err != nil {
+				return nil, fmt.Errorf("unable to marshal breaches for '%s': %w", r.Name, err)
+			}
+
+			problems = append(problems, Problem{
+				Identifier:  r.Name,
+				Version:     "1",
+				Source:      p.Source,
+				Service:     p.Service,
+				Data:        string(breachMapJson),
+				Severity:    SeverityTranslation(config.Severity(r.Severity)),
+				Description: description,
+				Links:       strings.Join(r.Links, ", "),
+			})
+			continue
+		}
+
+		for _, b := range r.ActiveBreaches() {
+			breachJson, err := json.Marshal(b)
+			if err != nil {
+				return nil, fmt.Errorf("unable to marshal breach for '%s': %w", r.Name, err)
+			}
+
+			severity := b.GetSeverity()
+			if severity == "" {
+				severity = r.Severity
+			}
+			problem := Problem{
+				// The fingerprint keeps the identifier unique and stable
+				// across runs.
+				Identifier:  r.Name + ":" + breach.Fingerprint(b)[:12],
+				Source:      p.Source,
+				Service:     p.Service,
+				Data:        string(breachJson),
+				Severity:    SeverityTranslation(config.Severity(severity)),
+				Description: description,
+				Links:       strings.Join(r.Links, ", "),
+			}
+			if r.Packages {
+				problem.AssociatedPackage, problem.Version = utils.ParsePackage(breach.BreachGetValue(b))
+			}
+			problems = append(problems, problem)
+		}
+	}
+	return problems, nil
+}
+
+// pushToInsightsRemote sends the problems to Insights Remote, retrying with
+// an exponential backoff if it fails because of a network error, a server
+// error or rate limiting.
+func (p *Lagoon) pushToInsightsRemote(problems []Problem, bearerToken string) error {
+	backoff, err := output.ParseDuration(p.Backoff, time.Second)
+	if err != nil {
+		return fmt.Errorf("invalid lagoon backoff: %w", err)
+	}
+
+	return output.Retry("pushing problems to Insights Remote", p.Retries, backoff, func() (bool, error) {
+		err := ProblemsToInsightsRemote(problems, p.InsightsRemoteEndpoint, bearerToken)
+		var statusErr *ErrInsightsRemoteStatus
+		if errors.As(err, &statusErr) {
+			return statusErr.Retryable(), err
+		}
+		// Network errors are retried, unlike e.g, failing to convert the
+		// problems to json.
+		var urlErr *url.Error
+		return errors.As(err, &urlErr), err
+	})
+}
+
+// AddProblems replaces the environment's problems from the source with the
+// given ones, using the Lagoon API.
+func (p *Lagoon) AddProblems(problems []Problem) error {
+	envId, err := GetEnvironmentId(p.Project, p.Environment)
+	if err != nil {
+		return err
+	}
+	if err := p.deleteProblems(envId); err != nil {
+		return err
+	}
+
+	for _, problem := range problems {
+		problem.EnvironmentId = envId
+		var m struct {
+			AddProblem struct {
+				Id int
+			} `graphql:"addProblem(input: $input)"`
+		}
+		variables := map[string]interface{}{"input": AddProblemInput(problem)}
+		if err := Client.Mutate(context.Background(), &m, variables); err != nil {
+			return fmt.Errorf("unable to add problem '%s': %w", problem.Identifier, err)
+		}
+	}
+	return nil
+}
+
 func (p *Lagoon) DeleteProblems() error {
 	log.WithFields(log.Fields{
 		"project":     p.Project,
@@ -129,13 +308,17 @@ func (p *Lagoon) DeleteProblems() error {
 	if err != nil {
 		return err
 	}
+	return p.deleteProblems(envId)
+}
+
+func (p *Lagoon) deleteProblems(envId int) error {
 	var m struct {
 		DeleteFactsFromSource string `graphql:"deleteProblemsFromSource(input: {environment: $envId, source: $sourceName, service:$service})"`
 	}
 	variables := map[string]interface{}{
 		"envId":      envId,
 		"sourceName": p.Source,
-		"service":    "",
+		"service":    p.Service,
 	}
 	return Client.Mutate(context.Background(), &m, variables)
 }
@@ -0,0 +1,323 @@
+package lagoon_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/internal"
+	"github.com/salsadigitalauorg/shipshape/pkg/lagoon"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func problemsResultList() *result.ResultList {
+	return &result.ResultList{
+		TotalBreaches: 3,
+		Results: []result.Result{
+			{
+				Name:        "base-images",
+				Description: "Base images are allowed",
+				Severity:    "high",
+				Links:       []string{"https://docs.lagoon.sh/docker-images/"},
+				Packages:    true,
+				Breaches: []breach.Breach{
+					&breach.ValueBreach{
+						BreachType: breach.BreachTypeValue,
+						CheckName:  "base-images",
+						Value:      "bitnami/kubectl:1.23",
+					},
+					&breach.ValueBreach{
+						BreachType: breach.BreachTypeValue,
+						CheckName:  "base-images",
+						Value:      "redis:5",
+						Severity:   "low",
+					},
+					&breach.ValueBreach{
+						BreachType: breach.BreachTypeValue,
+						CheckName:  "base-images",
+						Value:      "solr:7",
+						Waiver:     &breach.Waiver{Reason: "migrating"},
+					},
+				},
+			},
+			{Name: "tfa", Severity: "normal"},
+		},
+	}
+}
+
+func TestProblems(t *testing.T) {
+	t.Run("perResult", func(t *testing.T) {
+		assert := assert.New(t)
+		rl := problemsResultList()
+		o := lagoon.Lagoon{Source: "Shipshape", Service: "cli"}
+		problems, err := o.Problems(rl)
+		assert.NoError(err)
+
+		// Waived and suppressed breaches are left out.
+		breaches, _ := json.Marshal(rl.Results[0].ActiveBreaches())
+		assert.Equal([]lagoon.Problem{{
+			Identifier:  "base-images",
+			Version:     "1",
+			Source:      "Shipshape",
+			Service:     "cli",
+			Data:        string(breaches),
+			Severity:    "HIGH",
+			Description: "Base images are allowed",
+			Links:       "https://docs.lagoon.sh/docker-images/",
+		}}, problems)
+		assert.NotContains(problems[0].Data, "solr:7")
+
+		// Results with only waived or suppressed breaches have no problem.
+		rl.Results[0].Breaches = rl.Results[0].Breaches[2:]
+		problems, err = o.Problems(rl)
+		assert.NoError(err)
+		assert.Empty(problems)
+	})
+
+	t.Run("perBreach", func(t *testing.T) {
+		assert := assert.New(t)
+		rl := problemsResultList()
+		o := lagoon.Lagoon{Source: "Shipshape", Service: "cli", ProblemsPer: lagoon.ProblemsPerBreach}
+		problems, err := o.Problems(rl)
+		assert.NoError(err)
+
+		b0, b1 := rl.Results[0].Breaches[0], rl.Results[0].Breaches[1]
+		data0, _ := json.Marshal(b0)
+		data1, _ := json.Marshal(b1)
+		assert.Equal([]lagoon.Problem{
+			{
+				Identifier:        "base-images:" + breach.Fingerprint(b0)[:12],
+				Version:           "1.23",
+				Source:            "Shipshape",
+				Service:           "cli",
+				Data:              string(data0),
+				Severity:          "HIGH",
+				AssociatedPackage: "bitnami/kubectl",
+				Description:       "Base images are allowed",
+				Links:             "https://docs.lagoon.sh/docker-images/",
+			},
+			{
+				Identifier:        "base-images:" + breach.Fingerprint(b1)[:12],
+				Version:           "5",
+				Source:            "Shipshape",
+				Service:           "cli",
+				Data:              string(data1),
+				Severity:          "LOW",
+				AssociatedPackage: "redis",
+				Description:       "Base images are allowed",
+				Links:             "https://docs.lagoon.sh/docker-images/",
+			},
+		}, problems)
+	})
+
+	t.Run("perBreachNoPackages", func(t *testing.T) {
+		assert := assert.New(t)
+		rl := problemsResultList()
+		rl.Results[0].Packages = false
+		rl.Results[0].Description = ""
+		o := lagoon.Lagoon{ProblemsPer: lagoon.ProblemsPerBreach}
+		problems, err := o.Problems(rl)
+		assert.NoError(err)
+		assert.Len(problems, 2)
+		assert.Equal("", problems[0].AssociatedPackage)
+		assert.Equal("", problems[0].Version)
+		assert.Equal("base-images", problems[0].Description)
+	})
+
+	t.Run("unsupportedPer", func(t *testing.T) {
+		o := lagoon.Lagoon{ProblemsPer: "check"}
+		_, err := o.Problems(problemsResultList())
+		assert.EqualError(t, err, "unsupported lagoon problems-per 'check', expected one of: result, breach")
+	})
+}
+
+func TestAddProblems(t *testing.T) {
+	assert := assert.New(t)
+
+	svr := internal.MockLagoonServer()
+	lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+	origOutput := logrus.StandardLogger().Out
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	defer func() {
+		svr.Close()
+		internal.MockLagoonReset()
+		lagoon.Client = nil
+		logrus.SetOutput(origOutput)
+	}()
+
+	o := lagoon.Lagoon{Project: "foo", Environment: "bar", Source: "Shipshape", Service: "cli"}
+	err := o.AddProblems([]lagoon.Problem{{
+		Identifier: "tfa",
+		Source:     "Shipshape",
+		Service:    "cli",
+		Data:       "[]",
+		Severity:   "MEDIUM",
+	}})
+	assert.NoError(err)
+	assert.Equal(3, internal.MockLagoonNumCalls)
+	assert.Equal("{\"query\":\"mutation ($envId:Int!$service:String!$sourceName:String!)"+
+		"{deleteProblemsFromSource(input: {environment: $envId, source: "+
+		"$sourceName, service:$service})}\",\"variables\":{\"envId\":50,\"service\":\"cli\",\"sourceName\":"+
+		"\"Shipshape\"}}\n", internal.MockLagoonRequestBodies[1])
+	assert.Equal("{\"query\":\"mutation ($input:AddProblemInput!)"+
+		"{addProblem(input: $input){id}}\",\"variables\":{\"input\":{\"environment\":50,"+
+		"\"identifier\":\"tfa\",\"source\":\"Shipshape\",\"service\":\"cli\",\"data\":\"[]\","+
+		"\"severity\":\"MEDIUM\"}}}\n", internal.MockLagoonRequestBodies[2])
+}
+
+func TestOutputProblems(t *testing.T) {
+	origOutput := logrus.StandardLogger().Out
+	logrus.SetOutput(io.Discard)
+	defer logrus.SetOutput(origOutput)
+
+	t.Run("noInsightsToken", func(t *testing.T) {
+		assert := assert.New(t)
+		svr := internal.MockLagoonServer()
+		lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+		defer func() {
+			svr.Close()
+			internal.MockLagoonReset()
+			lagoon.Client = nil
+		}()
+
+		lagoon.InsightsTokenLocation = "testdata/missing"
+		defer func() { lagoon.InsightsTokenLocation = lagoon.DefaultLagoonInsightsTokenLocation }()
+
+		o := lagoon.Lagoon{
+			Project:                      "foo",
+			Environment:                  "bar",
+			Source:                       "Shipshape",
+			PushProblemsToInsightsRemote: true,
+			ProblemsPer:                  lagoon.ProblemsPerBreach,
+		}
+		out, err := o.Output(problemsResultList())
+		assert.NoError(err)
+		assert.Equal("successfully pushed problems to Lagoon\n", string(out))
+		// Environment id, deletion and a problem per active breach.
+		assert.Equal(4, internal.MockLagoonNumCalls)
+	})
+
+	t.Run("insightsRetries", func(t *testing.T) {
+		assert := assert.New(t)
+		calls := 0
+		var body []byte
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			body, _ = io.ReadAll(r.Body)
+			if calls == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte("unavailable"))
+			}
+		}))
+		defer svr.Close()
+		defer func() { lagoon.Client = nil }()
+
+		lagoon.InsightsTokenLocation = "testdata/insightsbearertoken"
+		defer func() { lagoon.InsightsTokenLocation = lagoon.DefaultLagoonInsightsTokenLocation }()
+
+		o := lagoon.Lagoon{
+			PushProblemsToInsightsRemote: true,
+			InsightsRemoteEndpoint:       svr.URL,
+			Source:                       "Shipshape",
+			Retries:                      1,
+			Backoff:                      "1ms",
+		}
+		out, err := o.Output(problemsResultList())
+		assert.NoError(err)
+		assert.Equal("successfully pushed problems to Lagoon Remote\n", string(out))
+		assert.Equal(2, calls)
+		problems := []lagoon.Problem{}
+		assert.NoError(json.Unmarshal(body, &problems))
+		assert.Len(problems, 1)
+	})
+
+	t.Run("factsFailure", func(t *testing.T) {
+		assert := assert.New(t)
+		apiSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer apiSvr.Close()
+		lagoon.Client = graphql.NewClient(apiSvr.URL, http.DefaultClient)
+		defer func() { lagoon.Client = nil }()
+
+		state := internal.MockInsightsRemoteTestState{}
+		svr := internal.MockRemoteInsightsServer(&state)
+		defer svr.Close()
+
+		lagoon.InsightsTokenLocation = "testdata/insightsbearertoken"
+		defer func() { lagoon.InsightsTokenLocation = lagoon.DefaultLagoonInsightsTokenLocation }()
+
+		o := lagoon.Lagoon{
+			Project:                      "foo",
+			Environment:                  "bar",
+			PublishFacts:                 true,
+			PushProblemsToInsightsRemote: true,
+			InsightsRemoteEndpoint:       svr.URL,
+		}
+		out, err := o.Output(problemsResultList())
+		assert.Nil(out)
+		assert.ErrorContains(err, "500 Internal Server Error")
+		// The problems are pushed regardless.
+		assert.NotEmpty(state.LastCallBody)
+	})
+
+	t.Run("insightsClientError", func(t *testing.T) {
+		assert := assert.New(t)
+		calls := 0
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("unauthorized"))
+		}))
+		defer svr.Close()
+		defer func() { lagoon.Client = nil }()
+
+		lagoon.InsightsTokenLocation = "testdata/insightsbearertoken"
+		defer func() { lagoon.InsightsTokenLocation = lagoon.DefaultLagoonInsightsTokenLocation }()
+
+		o := lagoon.Lagoon{
+			PushProblemsToInsightsRemote: true,
+			InsightsRemoteEndpoint:       svr.URL,
+			Retries:                      2,
+			Backoff:                      "1ms",
+		}
+		_, err := o.Output(problemsResultList())
+		assert.EqualError(err, "there was an error sending the problems to '"+svr.URL+"' : unauthorized")
+		// Client errors are not retried.
+		assert.Equal(1, calls)
+	})
+
+	t.Run("insightsRetriesExhausted", func(t *testing.T) {
+		assert := assert.New(t)
+		calls := 0
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("bad gateway"))
+		}))
+		defer svr.Close()
+		defer func() { lagoon.Client = nil }()
+
+		lagoon.InsightsTokenLocation = "testdata/insightsbearertoken"
+		defer func() { lagoon.InsightsTokenLocation = lagoon.DefaultLagoonInsightsTokenLocation }()
+
+		o := lagoon.Lagoon{
+			PushProblemsToInsightsRemote: true,
+			InsightsRemoteEndpoint:       svr.URL,
+			Retries:                      2,
+			Backoff:                      "1ms",
+		}
+		_, err := o.Output(problemsResultList())
+		assert.EqualError(err, "there was an error sending the problems to '"+svr.URL+"' : bad gateway")
+		assert.Equal(3, calls)
+	})
+}
@@ -0,0 +1,437 @@
+// Package migrate converts v1 checks configuration into the equivalent v2
+// collect & analyse configuration.
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/checks/drupal"
+	"github.com/salsadigitalauorg/shipshape/pkg/checks/file"
+	yamlcheck "github.com/salsadigitalauorg/shipshape/pkg/checks/yaml"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+)
+
+// entry is a key of a mapping, along with its value.
+type entry struct {
+	key   string
+	value interface{}
+}
+
+// migrator builds the v2 document from the v1 checks.
+type migrator struct {
+	collect *yaml.Node
+	analyse *yaml.Node
+	ids     map[string]bool
+	// todos holds the comments for the checks which could not be
+	// migrated.
+	todos []string
+}
+
+// Migrate converts a v1 config into a v2 config document. Checks, or parts
+// of them, which have no v2 equivalent are added as TODO comments.
+func Migrate(cfg config.Config) (*yaml.Node, error) {
+	m := &migrator{
+		collect: &yaml.Node{Kind: yaml.MappingNode},
+		analyse: &yaml.Node{Kind: yaml.MappingNode},
+		ids:     map[string]bool{},
+	}
+
+	checkTypes := []string{}
+	for ct := range cfg.Checks {
+		checkTypes = append(checkTypes, string(ct))
+	}
+	sort.Strings(checkTypes)
+
+	for _, ct := range checkTypes {
+		for _, c := range cfg.Checks[config.CheckType(ct)] {
+			var err error
+			switch c := c.(type) {
+			case *yamlcheck.YamlCheck:
+				err = m.yamlCheck(c)
+			case *file.FileCheck:
+				err = m.fileCheck(c)
+			case *drupal.FileModuleCheck:
+				err = m.fileModuleCheck(c)
+			default:
+				err = m.unsupportedCheck(config.CheckType(ct), c,
+					fmt.Sprintf("check type '%s' has no v2 equivalent", ct))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	root.Content = append(root.Content,
+		scalar("collect"), m.collect,
+		scalar("analyse"), m.analyse)
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+	if len(m.todos) > 0 {
+		doc.FootComment = strings.Join(m.todos, "\n\n")
+	}
+	return doc, nil
+}
+
+// yamlCheck converts a yaml check to a file:read or file:lookup fact, with
+// a yaml:key fact and an analyser for each of its values.
+func (m *migrator) yamlCheck(c *yamlcheck.YamlCheck) error {
+	switch {
+	case c.File != "":
+		return m.yamlFile(c, c.Name, c.File)
+	case len(c.Files) > 0:
+		for _, f := range c.Files {
+			if err := m.yamlFile(c, c.Name+" "+f, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case c.Pattern != "":
+		fileId := m.uniqueId(slug(c.Name) + "-files")
+		if err := m.addPlugin(m.collect, fileId, "file:lookup", []entry{
+			{"path", defaultPath(c.Path)},
+			{"pattern", c.Pattern},
+			{"exclude-pattern", c.ExcludePattern},
+			{"file-names-only", false},
+		}, nil); err != nil {
+			return err
+		}
+		return m.yamlValues(c.Name, severity(c.CheckBase), fileId, c.Values, true)
+	}
+	return m.unsupportedCheck(yamlcheck.Yaml, c, "no file or pattern provided")
+}
+
+// yamlFile converts the values of a yaml check for a single file.
+func (m *migrator) yamlFile(c *yamlcheck.YamlCheck, name string, f string) error {
+	var comments []string
+	if c.IgnoreMissing != nil && *c.IgnoreMissing {
+		comments = append(comments, "TODO: ignore-missing has no v2 equivalent; "+
+			"file:read reports missing files as errors.")
+	}
+
+	fileId := m.uniqueId(slug(name) + "-file")
+	if err := m.addPlugin(m.collect, fileId, "file:read", []entry{
+		{"path", filepath.Join(c.Path, f)},
+	}, comments); err != nil {
+		return err
+	}
+	return m.yamlValues(name, severity(c.CheckBase), fileId, c.Values, false)
+}
+
+// yamlValues adds a yaml:key fact and an analyser for each value to verify.
+// multi determines whether the input contains multiple files.
+func (m *migrator) yamlValues(name string, sev string, input string, values []yamlcheck.KeyValue, multi bool) error {
+	for _, kv := range values {
+		id := m.uniqueId(slug(name) + "-" + slug(kv.Key))
+		fields := []entry{{"input", input}, {"path", kv.Key}}
+		if kv.Optional {
+			fields = append(fields, entry{"ignore-not-found", true})
+		}
+		if err := m.addPlugin(m.collect, id, "yaml:key", fields, nil); err != nil {
+			return err
+		}
+
+		analyser, analyserFields, todo := keyValueAnalyser(kv, multi)
+		if analyser == "" {
+			if err := m.unsupportedValue(id, name, kv, todo); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var comments []string
+		if todo != "" {
+			comments = append(comments, "TODO: "+todo)
+		}
+		fields = append([]entry{
+			{"description", name},
+			{"severity", sev},
+			{"input", id},
+		}, analyserFields...)
+		if err := m.addPlugin(m.analyse, id, analyser, fields, comments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyValueAnalyser determines the analyser equivalent to a v1 KeyValue
+// verification, along with its fields. If there is no equivalent, the
+// analyser is empty and the returned note explains why.
+func keyValueAnalyser(kv yamlcheck.KeyValue, multi bool) (string, []entry, string) {
+	// Single values can be verified using a regex, while lists and values
+	// from multiple files are verified using the allowed:list analyser.
+	scalar := !multi && !kv.IsList
+
+	if len(kv.Allowed) == 0 && len(kv.Disallowed) == 0 {
+		if kv.IsList {
+			return "", nil, "is-list requires a list of allowed or disallowed values"
+		}
+
+		var expected []string
+		if kv.Truthy {
+			expected = truthyValues(kv.Value)
+			if expected == nil {
+				return "", nil, fmt.Sprintf("truthy value '%s' is neither true nor false", kv.Value)
+			}
+		} else if scalar {
+			return "not:equals", []entry{{"value", kv.Value}}, ""
+		} else {
+			expected = []string{kv.Value}
+		}
+
+		if scalar {
+			return "regex:not-match", []entry{{"pattern", anyOfPattern(expected)}}, ""
+		}
+		return "allowed:list", []entry{{"allowed", expected}}, ""
+	}
+
+	if scalar {
+		if len(kv.Allowed) == 0 {
+			return "regex:match", []entry{{"pattern", anyOfPattern(kv.Disallowed)}}, ""
+		}
+		allowed := []string{}
+		for _, v := range kv.Allowed {
+			if !contains(kv.Disallowed, v) {
+				allowed = append(allowed, v)
+			}
+		}
+		return "regex:not-match", []entry{{"pattern", anyOfPattern(allowed)}}, ""
+	}
+
+	if len(kv.Allowed) == 0 {
+		return "", nil, "disallowed values without a list of allowed values " +
+			"are only supported for single values"
+	}
+	fields := []entry{{"allowed", kv.Allowed}}
+	if len(kv.Disallowed) > 0 {
+		fields = append(fields, entry{"deprecated", kv.Disallowed})
+	}
+	return "allowed:list", fields, ""
+}
+
+// fileCheck converts a file check to a file:lookup fact, along with an
+// allowed:list analyser for which every file found is a breach.
+func (m *migrator) fileCheck(c *file.FileCheck) error {
+	id := m.uniqueId(slug(c.Name))
+	if err := m.addPlugin(m.collect, id, "file:lookup", []entry{
+		{"path", defaultPath(c.Path)},
+		{"pattern", c.DisallowedPattern},
+		{"exclude-pattern", c.ExcludePattern},
+		{"skip-dirs", c.SkipDir},
+	}, nil); err != nil {
+		return err
+	}
+
+	return m.addPlugin(m.analyse, id, "allowed:list", []entry{
+		{"description", c.Name},
+		{"severity", severity(c.CheckBase)},
+		{"input", id},
+	}, []string{"Any file found is disallowed."})
+}
+
+// fileModuleCheck converts a drupal-file-module check to a file:read fact
+// of the core.extension.yml file, with a yaml:key fact for the module names
+// verified by an allowed:list analyser.
+func (m *migrator) fileModuleCheck(c *drupal.FileModuleCheck) error {
+	if len(c.Required) == 0 && len(c.Disallowed) > 0 {
+		return m.unsupportedCheck(drupal.FileModule, c,
+			"disallowed modules without required modules have no v2 equivalent")
+	}
+
+	fileId := m.uniqueId(slug(c.Name) + "-file")
+	if err := m.addPlugin(m.collect, fileId, "file:read", []entry{
+		{"path", filepath.Join(c.Path, "core.extension.yml")},
+	}, nil); err != nil {
+		return err
+	}
+
+	id := m.uniqueId(slug(c.Name) + "-modules")
+	if err := m.addPlugin(m.collect, id, "yaml:key", []entry{
+		{"input", fileId},
+		{"path", "module"},
+		{"keys-only", true},
+	}, nil); err != nil {
+		return err
+	}
+
+	return m.addPlugin(m.analyse, id, "allowed:list", []entry{
+		{"description", c.Name},
+		{"severity", severity(c.CheckBase)},
+		{"input", id},
+		{"required", c.Required},
+		{"deprecated", c.Disallowed},
+	}, nil)
+}
+
+// unsupportedCheck adds a TODO comment for a check which could not be
+// migrated, containing its original configuration.
+func (m *migrator) unsupportedCheck(ct config.CheckType, c config.Check, reason string) error {
+	n := &yaml.Node{}
+	if err := n.Encode(map[string][]config.Check{string(ct): {c}}); err != nil {
+		return err
+	}
+	pruneEmpty(n)
+	return m.addUnsupported(fmt.Sprintf("TODO: check '%s' could not be migrated: %s.",
+		c.GetName(), reason), n)
+}
+
+// unsupportedValue adds a TODO comment for a yaml value whose verification could
+// not be migrated; its yaml:key fact is still collected.
+func (m *migrator) unsupportedValue(id string, name string, kv yamlcheck.KeyValue, reason string) error {
+	n := &yaml.Node{}
+	if err := n.Encode(kv); err != nil {
+		return err
+	}
+	pruneEmpty(n)
+	return m.addUnsupported(fmt.Sprintf(
+		"TODO: value '%s' of check '%s' could not be migrated: %s; "+
+			"its data is collected by the fact '%s'.", kv.Key, name, reason, id), n)
+}
+
+func (m *migrator) addUnsupported(msg string, n *yaml.Node) error {
+	out, err := yaml.Marshal(n)
+	if err != nil {
+		return err
+	}
+	lines := []string{msg}
+	for _, l := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		lines = append(lines, "  "+l)
+	}
+	m.todos = append(m.todos, strings.Join(lines, "\n"))
+	return nil
+}
+
+// addPlugin adds the configuration of a plugin to a section of the document,
+// omitting empty fields.
+func (m *migrator) addPlugin(section *yaml.Node, id string, plugin string, fields []entry, comments []string) error {
+	pluginNode := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range fields {
+		if isEmpty(f.value) {
+			continue
+		}
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(f.value); err != nil {
+			return err
+		}
+		pluginNode.Content = append(pluginNode.Content, scalar(f.key), valueNode)
+	}
+
+	keyNode := scalar(id)
+	keyNode.HeadComment = strings.Join(comments, "\n")
+	section.Content = append(section.Content, keyNode, &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: []*yaml.Node{scalar(plugin), pluginNode},
+	})
+	return nil
+}
+
+// uniqueId returns the given id, suffixed by a number if it already exists.
+func (m *migrator) uniqueId(id string) string {
+	unique := id
+	for i := 2; m.ids[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", id, i)
+	}
+	m.ids[unique] = true
+	return unique
+}
+
+func scalar(v string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+// pruneEmpty removes the keys with empty values from the mappings of a node,
+// as well as the ones used internally by the v1 checks.
+func pruneEmpty(n *yaml.Node) {
+	for _, c := range n.Content {
+		pruneEmpty(c)
+	}
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+
+	content := []*yaml.Node{}
+	for i := 0; i < len(n.Content)-1; i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if k.Value == "node" || k.Value == "nodemap" {
+			continue
+		}
+		if (v.Kind == yaml.ScalarNode && (v.Value == "" || v.Tag == "!!null")) ||
+			((v.Kind == yaml.MappingNode || v.Kind == yaml.SequenceNode) && len(v.Content) == 0) {
+			continue
+		}
+		content = append(content, k, v)
+	}
+	n.Content = content
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug converts a check name to an id.
+func slug(name string) string {
+	s := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if s == "" {
+		return "check"
+	}
+	return s
+}
+
+func severity(c config.CheckBase) string {
+	if c.Severity == "" {
+		return string(config.NormalSeverity)
+	}
+	return string(c.Severity)
+}
+
+func defaultPath(p string) string {
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// truthyValues returns the values considered equal to a v1 truthy value.
+func truthyValues(v string) []string {
+	switch v {
+	case "1", "true":
+		return []string{"1", "true"}
+	case "0", "false", "null":
+		return []string{"0", "false", "null"}
+	}
+	return nil
+}
+
+// anyOfPattern returns a regex matching any of the given values exactly.
+func anyOfPattern(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
+
+func contains(list []string, v string) bool {
+	for _, l := range list {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,265 @@
+package migrate_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	. "github.com/salsadigitalauorg/shipshape/pkg/migrate"
+)
+
+func migrate(t *testing.T, configData ...string) string {
+	t.Helper()
+
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	data := [][]byte{}
+	for _, d := range configData {
+		data = append(data, []byte(d))
+	}
+	isV2, cfg, _, err := config.ParseConfigData(data)
+	if !assert.NoError(t, err) || !assert.False(t, isV2) {
+		t.FailNow()
+	}
+
+	doc, err := Migrate(cfg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	out, err := yaml.Marshal(doc)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return string(out)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("yamlSingleFile", func(t *testing.T) {
+		out := migrate(t, `
+checks:
+  yaml:
+    - name: Views cache
+      severity: high
+      file: views.view.content.yml
+      path: config/sync
+      values:
+        - key: display.default.cache.type
+          value: tag
+        - key: status
+          allowed: ["true"]
+          optional: true
+        - key: langcode
+          disallowed: [fr, de]
+        - key: dependencies.module
+          is-list: true
+          allowed: [node, user]
+          disallowed: [views_ui]
+`)
+		assert.Equal(t, `collect:
+    views-cache-file:
+        file:read:
+            path: config/sync/views.view.content.yml
+    views-cache-display-default-cache-type:
+        yaml:key:
+            input: views-cache-file
+            path: display.default.cache.type
+    views-cache-status:
+        yaml:key:
+            input: views-cache-file
+            path: status
+            ignore-not-found: true
+    views-cache-langcode:
+        yaml:key:
+            input: views-cache-file
+            path: langcode
+    views-cache-dependencies-module:
+        yaml:key:
+            input: views-cache-file
+            path: dependencies.module
+analyse:
+    views-cache-display-default-cache-type:
+        not:equals:
+            description: Views cache
+            severity: high
+            input: views-cache-display-default-cache-type
+            value: tag
+    views-cache-status:
+        regex:not-match:
+            description: Views cache
+            severity: high
+            input: views-cache-status
+            pattern: ^(?:true)$
+    views-cache-langcode:
+        regex:match:
+            description: Views cache
+            severity: high
+            input: views-cache-langcode
+            pattern: ^(?:fr|de)$
+    views-cache-dependencies-module:
+        allowed:list:
+            description: Views cache
+            severity: high
+            input: views-cache-dependencies-module
+            allowed:
+                - node
+                - user
+            deprecated:
+                - views_ui
+`, out)
+	})
+
+	t.Run("yamlPattern", func(t *testing.T) {
+		out := migrate(t, `
+checks:
+  yaml:
+    - name: Disabled modules
+      path: config
+      pattern: ".*.settings.yml"
+      exclude-pattern: "^core"
+      values:
+        - key: enabled
+          truthy: true
+          value: "false"
+        - key: handler
+          disallowed: [dblog]
+`)
+		assert.Equal(t, `collect:
+    disabled-modules-files:
+        file:lookup:
+            path: config
+            pattern: .*.settings.yml
+            exclude-pattern: ^core
+            file-names-only: false
+    disabled-modules-enabled:
+        yaml:key:
+            input: disabled-modules-files
+            path: enabled
+    disabled-modules-handler:
+        yaml:key:
+            input: disabled-modules-files
+            path: handler
+analyse:
+    disabled-modules-enabled:
+        allowed:list:
+            description: Disabled modules
+            severity: normal
+            input: disabled-modules-enabled
+            allowed:
+                - "0"
+                - "false"
+                - "null"
+
+# TODO: value 'handler' of check 'Disabled modules' could not be migrated: disallowed values without a list of allowed values are only supported for single values; its data is collected by the fact 'disabled-modules-handler'.
+#   key: handler
+#   truthy: false
+#   is-list: false
+#   optional: false
+#   disallowed:
+#       - dblog
+`, out)
+	})
+
+	t.Run("mergedFiles", func(t *testing.T) {
+		out := migrate(t, `
+checks:
+  file:
+    - name: Illegal files
+      path: web
+      skip-dir: [core]
+      disallowed-pattern: '^(adminer|phpinfo).*\.php$'
+  drupal-file-module:
+    - name: Modules
+      required: [clamav]
+      disallowed: [devel]
+`, `
+checks:
+  file:
+    - name: Illegal files
+      path: docroot
+  drupal-db-module:
+    - name: Db modules
+      required: [clamav]
+`)
+		assert.Equal(t, `collect:
+    modules-file:
+        file:read:
+            path: core.extension.yml
+    modules-modules:
+        yaml:key:
+            input: modules-file
+            path: module
+            keys-only: true
+    illegal-files:
+        file:lookup:
+            path: docroot
+            pattern: ^(adminer|phpinfo).*\.php$
+            skip-dirs:
+                - core
+analyse:
+    modules-modules:
+        allowed:list:
+            description: Modules
+            severity: normal
+            input: modules-modules
+            required:
+                - clamav
+            deprecated:
+                - devel
+    # Any file found is disallowed.
+    illegal-files:
+        allowed:list:
+            description: Illegal files
+            severity: normal
+            input: illegal-files
+
+# TODO: check 'Db modules' could not be migrated: check type 'drupal-db-module' has no v2 equivalent.
+#   drupal-db-module:
+#       - name: Db modules
+#         required:
+#           - clamav
+`, out)
+	})
+
+	t.Run("yamlFiles", func(t *testing.T) {
+		out := migrate(t, `
+checks:
+  yaml:
+    - name: Site
+      path: config
+      files: [a.yml, b.yml]
+      values:
+        - key: name
+          value: foo
+`)
+		assert.Contains(t, out, "    site-a-yml-file:\n        file:read:\n            path: config/a.yml\n")
+		assert.Contains(t, out, "    site-b-yml-file:\n        file:read:\n            path: config/b.yml\n")
+		assert.Contains(t, out, "            input: site-b-yml-file\n            path: name\n")
+	})
+
+	t.Run("uniqueIds", func(t *testing.T) {
+		out := migrate(t, `
+checks:
+  yaml:
+    - name: Site
+      file: a.yml
+      values:
+        - key: name
+          value: foo
+    - name: Site!
+      file: a.yml
+      values:
+        - key: name
+          value: bar
+`)
+		assert.Contains(t, out, "    site-file:\n")
+		assert.Contains(t, out, "    site-file-2:\n")
+		assert.Contains(t, out, "    site-name:\n")
+		assert.Contains(t, out, "    site-name-2:\n")
+	})
+}
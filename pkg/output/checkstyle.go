@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// CheckstyleSeverity maps a severity to a Checkstyle severity.
+func CheckstyleSeverity(severity string) string {
+	if level := SarifLevel(severity); level != "note" {
+		return level
+	}
+	return "info"
+}
+
+// Checkstyle outputs the breaches in the Checkstyle XML format, e.g, for
+// Jenkins' warnings plugin. Breaches without a location are reported against
+// the project's root.
+func (p *Stdout) Checkstyle(rl *result.ResultList, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+
+	files := map[string]*CheckstyleFile{}
+	for _, r := range rl.Results {
+		for _, b := range unresolvedBreaches(r) {
+			name := ProjectRootPath
+			e := CheckstyleError{
+				Severity: CheckstyleSeverity(breachSeverity(r, b)),
+				Message:  b.String(),
+				Source:   "shipshape." + breachCheckName(r, b),
+			}
+			if l := b.GetLocation(); l != nil && l.File != "" {
+				name = l.File
+				e.Line = l.Line
+				e.Column = l.Column
+			}
+			if _, ok := files[name]; !ok {
+				files[name] = &CheckstyleFile{Name: name}
+			}
+			files[name].Errors = append(files[name].Errors, e)
+		}
+	}
+
+	cs := Checkstyle{Version: "4.3", Files: []CheckstyleFile{}}
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cs.Files = append(cs.Files, *files[name])
+	}
+
+	xmlBytes, err := xml.MarshalIndent(cs, "", "    ")
+	if err != nil {
+		fmt.Fprintf(buf, "error occurred while converting to XML: %s\n", err.Error())
+		return
+	}
+	fmt.Fprint(buf, xml.Header)
+	fmt.Fprint(buf, string(xmlBytes))
+	fmt.Fprintln(buf)
+}
@@ -0,0 +1,38 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestCheckstyle(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := ciResultList()
+	var buf bytes.Buffer
+	(&Stdout{}).Checkstyle(&rl, &buf)
+	assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+    <file name=".">
+        <error severity="info" message="no location" source="shipshape.admin"></error>
+    </file>
+    <file name="config/user.role.yml">
+        <error line="3" column="5" severity="error" message="50% of&#xA;roles" source="shipshape.admin"></error>
+    </file>
+</checkstyle>
+`, buf.String())
+
+	t.Run("noBreach", func(t *testing.T) {
+		rl := result.NewResultList(false)
+		buf.Reset()
+		(&Stdout{}).Checkstyle(&rl, &buf)
+		assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3"></checkstyle>
+`, buf.String())
+	})
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// CodeQualityLevel maps a severity to a Code Quality severity.
+func CodeQualityLevel(severity string) string {
+	switch config.Severity(severity) {
+	case config.LowSeverity:
+		return "minor"
+	case config.NormalSeverity:
+		return "major"
+	case config.HighSeverity:
+		return "critical"
+	case config.CriticalSeverity:
+		return "blocker"
+	}
+	return "info"
+}
+
+// CodeQuality outputs the breaches as a GitLab Code Quality report, which
+// displays them in merge requests. Breaches without a location are reported
+// against the project's root.
+func (p *Stdout) CodeQuality(rl *result.ResultList, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+
+	issues := []CodeQualityIssue{}
+	for _, r := range rl.Results {
+		for _, b := range unresolvedBreaches(r) {
+			issue := CodeQualityIssue{
+				Description: b.String(),
+				CheckName:   breachCheckName(r, b),
+				Fingerprint: breach.Fingerprint(b),
+				Severity:    CodeQualityLevel(breachSeverity(r, b)),
+				Location:    CodeQualityLocation{Path: ProjectRootPath},
+			}
+			if l := b.GetLocation(); l != nil && l.File != "" {
+				issue.Location.Path = l.File
+				if l.Line > 0 {
+					issue.Location.Lines = &CodeQualityLines{Begin: l.Line, End: l.EndLine}
+				}
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		fmt.Fprintf(buf, "error occurred while converting to JSON: %s\n", err.Error())
+		return
+	}
+	fmt.Fprintln(buf, string(data))
+}
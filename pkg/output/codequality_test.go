@@ -0,0 +1,52 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+)
+
+func TestCodeQuality(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := ciResultList()
+	var buf bytes.Buffer
+	(&Stdout{}).CodeQuality(&rl, &buf)
+
+	issues := []CodeQualityIssue{}
+	assert.NoError(json.Unmarshal(buf.Bytes(), &issues))
+	assert.Len(issues, 2)
+
+	assert.Equal("50% of\nroles", issues[0].Description)
+	assert.Equal("admin", issues[0].CheckName)
+	assert.Equal("critical", issues[0].Severity)
+	assert.Len(issues[0].Fingerprint, 64)
+	assert.Equal(CodeQualityLocation{
+		Path:  "config/user.role.yml",
+		Lines: &CodeQualityLines{Begin: 3, End: 4},
+	}, issues[0].Location)
+
+	assert.Equal("minor", issues[1].Severity)
+	assert.Equal(CodeQualityLocation{Path: "."}, issues[1].Location)
+	assert.NotEqual(issues[0].Fingerprint, issues[1].Fingerprint)
+
+	t.Run("noBreach", func(t *testing.T) {
+		rl.Results = rl.Results[1:]
+		buf.Reset()
+		(&Stdout{}).CodeQuality(&rl, &buf)
+		assert.Equal("[]\n", buf.String())
+	})
+}
+
+func TestCodeQualityLevel(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("minor", CodeQualityLevel("low"))
+	assert.Equal("major", CodeQualityLevel("normal"))
+	assert.Equal("critical", CodeQualityLevel("high"))
+	assert.Equal("blocker", CodeQualityLevel("critical"))
+	assert.Equal("info", CodeQualityLevel(""))
+}
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+var DiffFormats = []string{"json", "markdown", "pretty"}
+
+// Diff outputs the differences between two result lists in the given format.
+func Diff(d *result.Diff, format string, w io.Writer) error {
+	switch format {
+	case "pretty":
+		DiffPretty(d, w)
+	case "markdown":
+		DiffMarkdown(d, w)
+	case "json":
+		data, err := json.Marshal(d)
+		if err != nil {
+			return fmt.Errorf("unable to convert diff to json: %+v", err)
+		}
+		fmt.Fprintln(w, string(data))
+	default:
+		return fmt.Errorf("unsupported diff format '%s', expected one of: %s",
+			format, strings.Join(DiffFormats, ", "))
+	}
+	return nil
+}
+
+// DiffPretty outputs the new and resolved breaches, followed by the
+// analysers whose status or severity changed.
+func DiffPretty(d *result.Diff, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+
+	if d.Empty() {
+		fmt.Fprint(buf, "No change detected.\n")
+		return
+	}
+
+	printBreaches := func(title string, breaches []result.DiffBreach) {
+		if len(breaches) == 0 {
+			return
+		}
+		fmt.Fprintf(buf, "# %s (%d)\n\n", title, len(breaches))
+		analyser := ""
+		for _, db := range breaches {
+			if db.Analyser != analyser {
+				if analyser != "" {
+					fmt.Fprintln(buf)
+				}
+				analyser = db.Analyser
+				fmt.Fprintf(buf, "  ### %s\n", analyser)
+			}
+			fmt.Fprintf(buf, "     -- [%s] %s\n", db.Severity, db.Breach)
+			if loc := db.Breach.GetLocation(); loc != nil {
+				fmt.Fprintf(buf, "        at %s\n", loc)
+			}
+		}
+		fmt.Fprintln(buf)
+	}
+	printBreaches("New breaches", d.New)
+	printBreaches("Resolved breaches", d.Resolved)
+
+	if len(d.Changed) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "# Changed analysers (%d)\n\n", len(d.Changed))
+	for _, c := range d.Changed {
+		fmt.Fprintf(buf, "  ### %s\n", c.Analyser)
+		if c.OldStatus != c.NewStatus {
+			fmt.Fprintf(buf, "     -- status: %s -> %s\n", c.OldStatus, c.NewStatus)
+		}
+		if c.OldSeverity != c.NewSeverity {
+			fmt.Fprintf(buf, "     -- severity: %s -> %s\n", c.OldSeverity, c.NewSeverity)
+		}
+	}
+	fmt.Fprintln(buf)
+}
+
+// DiffMarkdown outputs the differences as markdown tables, e.g, for a pull
+// request comment.
+func DiffMarkdown(d *result.Diff, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+
+	if d.Empty() {
+		fmt.Fprint(buf, "No change detected.\n")
+		return
+	}
+
+	printBreaches := func(title string, breaches []result.DiffBreach) {
+		if len(breaches) == 0 {
+			return
+		}
+		fmt.Fprintf(buf, "## %s (%d)\n\n", title, len(breaches))
+		fmt.Fprint(buf, "| Analyser | Severity | Breach | Location |\n")
+		fmt.Fprint(buf, "|---|---|---|---|\n")
+		for _, db := range breaches {
+			loc := ""
+			if l := db.Breach.GetLocation(); l != nil {
+				loc = l.String()
+			}
+			fmt.Fprintf(buf, "| %s | %s | %s | %s |\n", markdownCell(db.Analyser),
+				db.Severity, markdownCell(db.Breach.String()), markdownCell(loc))
+		}
+		fmt.Fprintln(buf)
+	}
+	printBreaches("New breaches", d.New)
+	printBreaches("Resolved breaches", d.Resolved)
+
+	if len(d.Changed) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## Changed analysers (%d)\n\n", len(d.Changed))
+	fmt.Fprint(buf, "| Analyser | Status | Severity |\n")
+	fmt.Fprint(buf, "|---|---|---|\n")
+	for _, c := range d.Changed {
+		status := string(c.NewStatus)
+		if c.OldStatus != c.NewStatus {
+			status = fmt.Sprintf("%s → %s", c.OldStatus, c.NewStatus)
+		}
+		severity := c.NewSeverity
+		if c.OldSeverity != c.NewSeverity {
+			severity = fmt.Sprintf("%s → %s", c.OldSeverity, c.NewSeverity)
+		}
+		fmt.Fprintf(buf, "| %s | %s | %s |\n", markdownCell(c.Analyser), status, severity)
+	}
+	fmt.Fprintln(buf)
+}
+
+// markdownCell escapes a value for use in a markdown table cell, where pipes
+// and line breaks would break the table.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.Join(lines, "<br>")
+}
@@ -0,0 +1,101 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestDiff(t *testing.T) {
+	d := &result.Diff{
+		New: []result.DiffBreach{{
+			Analyser: "images",
+			Severity: "high",
+			Breach: &breach.KeyValueBreach{
+				KeyLabel: "service", Key: "nginx", ValueLabel: "image", Value: "nginx|latest",
+				Location: &breach.Location{File: "docker-compose.yml", Line: 3},
+			},
+		}},
+		Resolved: []result.DiffBreach{{
+			Analyser: "modules",
+			Severity: "normal",
+			Breach:   &breach.ValueBreach{Value: "devel"},
+		}},
+		Changed: []result.DiffChange{{
+			Analyser:  "images",
+			OldStatus: result.Pass, NewStatus: result.Fail,
+			OldSeverity: "high", NewSeverity: "high",
+		}},
+	}
+
+	tt := []struct {
+		name        string
+		diff        *result.Diff
+		format      string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "prettyEmpty",
+			diff:     &result.Diff{},
+			format:   "pretty",
+			expected: "No change detected.\n",
+		},
+		{
+			name:   "pretty",
+			diff:   d,
+			format: "pretty",
+			expected: "# New breaches (1)\n\n  ### images\n" +
+				"     -- [high] [service:nginx] image: nginx|latest\n" +
+				"        at docker-compose.yml:3\n\n" +
+				"# Resolved breaches (1)\n\n  ### modules\n     -- [normal] devel\n\n" +
+				"# Changed analysers (1)\n\n  ### images\n     -- status: Pass -> Fail\n\n",
+		},
+		{
+			name:   "markdown",
+			diff:   d,
+			format: "markdown",
+			expected: "## New breaches (1)\n\n" +
+				"| Analyser | Severity | Breach | Location |\n|---|---|---|---|\n" +
+				"| images | high | [service:nginx] image: nginx\\|latest | docker-compose.yml:3 |\n\n" +
+				"## Resolved breaches (1)\n\n" +
+				"| Analyser | Severity | Breach | Location |\n|---|---|---|---|\n" +
+				"| modules | normal | devel |  |\n\n" +
+				"## Changed analysers (1)\n\n" +
+				"| Analyser | Status | Severity |\n|---|---|---|\n" +
+				"| images | Pass → Fail | high |\n\n",
+		},
+		{
+			name:   "json",
+			diff:   &result.Diff{Changed: d.Changed},
+			format: "json",
+			expected: `{"new":null,"resolved":null,"changed":[{"analyser":"images",` +
+				`"old-status":"Pass","new-status":"Fail","old-severity":"high","new-severity":"high"}]}` + "\n",
+		},
+		{
+			name:        "unsupported",
+			diff:        d,
+			format:      "table",
+			expectedErr: "unsupported diff format 'table', expected one of: json, markdown, pretty",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			var buf bytes.Buffer
+			err := Diff(tc.diff, tc.format, &buf)
+			if tc.expectedErr != "" {
+				assert.EqualError(err, tc.expectedErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expected, buf.String())
+		})
+	}
+}
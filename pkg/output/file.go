@@ -1 +1,130 @@
 package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+)
+
+// DefaultFilename is the template for the name of files written to a
+// directory.
+const DefaultFilename = "shipshape-{{ .Timestamp }}.{{ .Ext }}"
+
+// FormatExtensions maps the output formats to their file extension.
+var FormatExtensions = map[string]string{
+	"checkstyle":  "xml",
+	"codequality": "json",
+	"github":      "txt",
+	"html":        "html",
+	"json":        "json",
+	"junit":       "xml",
+	"markdown":    "md",
+	"pretty":      "txt",
+	"sarif":       "sarif",
+	"table":       "txt",
+}
+
+// File writes the results to a file, in any of the stdout formats.
+type File struct {
+	// Plugin-specific fields.
+	// Format is the output format; see Stdout.
+	Format string `yaml:"format"`
+	// Path is the file to write to.
+	Path string `yaml:"path"`
+	// Dir is the directory to write to when Path is not set, using Filename
+	// as the name of the file.
+	Dir string `yaml:"dir"`
+	// Filename is a template for the name of the file written to Dir, e.g,
+	// "{{ .Date }}-shipshape.{{ .Ext }}". Available fields are Format, Ext,
+	// Date (2006-01-02) and Timestamp (20060102-150405).
+	Filename string `yaml:"filename"`
+}
+
+// FilenameData is the data available to the filename template.
+type FilenameData struct {
+	Format    string
+	Ext       string
+	Date      string
+	Timestamp string
+}
+
+var f = &File{Format: "json"}
+
+func init() {
+	Outputters["file"] = f
+	Factories["file"] = func() Outputter { return &File{Format: "json"} }
+}
+
+// Output writes the results to the file, if configured, returning no output.
+func (p *File) Output(rl *result.ResultList) ([]byte, error) {
+	if p.Path == "" && p.Dir == "" {
+		log.Debug("skipping file output, no path or dir configured")
+		return nil, nil
+	}
+
+	if !utils.StringSliceContains(OutputFormats, p.Format) {
+		return nil, fmt.Errorf("unsupported output format '%s' for file output, expected one of: %s",
+			p.Format, strings.Join(OutputFormats, ", "))
+	}
+
+	path, err := p.Destination(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := (&Stdout{Format: p.Format}).Output(rl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for '%s': %w", path, err)
+	}
+	log.WithFields(log.Fields{"path": path, "format": p.Format}).
+		Info("writing results to file")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write results to '%s': %w", path, err)
+	}
+	return nil, nil
+}
+
+// Destination determines the path of the file to write to, rendering the
+// filename template if writing to a directory.
+func (p *File) Destination(now time.Time) (string, error) {
+	if p.Path != "" {
+		return p.Path, nil
+	}
+
+	filename := p.Filename
+	if filename == "" {
+		filename = DefaultFilename
+	}
+	tmpl, err := template.New("filename").Option("missingkey=error").Parse(filename)
+	if err != nil {
+		return "", fmt.Errorf("invalid filename template '%s': %w", filename, err)
+	}
+
+	ext, ok := FormatExtensions[p.Format]
+	if !ok {
+		ext = p.Format
+	}
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, FilenameData{
+		Format:    p.Format,
+		Ext:       ext,
+		Date:      now.Format("2006-01-02"),
+		Timestamp: now.Format("20060102-150405"),
+	}); err != nil {
+		return "", fmt.Errorf("invalid filename template '%s': %w", filename, err)
+	}
+	return filepath.Join(p.Dir, buf.String()), nil
+}
@@ -0,0 +1,102 @@
+package output_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestFileDestination(t *testing.T) {
+	now := time.Date(2026, 10, 18, 1, 2, 3, 0, time.UTC)
+	tt := []struct {
+		name        string
+		file        File
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "path",
+			file:     File{Format: "json", Path: "results.json", Dir: "reports"},
+			expected: "results.json",
+		},
+		{
+			name:     "dirDefaultFilename",
+			file:     File{Format: "junit", Dir: "reports"},
+			expected: "reports/shipshape-20261018-010203.xml",
+		},
+		{
+			name:     "dirFilename",
+			file:     File{Format: "markdown", Dir: "reports", Filename: "{{ .Date }}-{{ .Format }}.{{ .Ext }}"},
+			expected: "reports/2026-10-18-markdown.md",
+		},
+		{
+			name:        "invalidTemplate",
+			file:        File{Format: "json", Dir: "reports", Filename: "{{ .Date"},
+			expectedErr: "invalid filename template '{{ .Date'",
+		},
+		{
+			name:        "unknownField",
+			file:        File{Format: "json", Dir: "reports", Filename: "{{ .Foo }}"},
+			expectedErr: "invalid filename template '{{ .Foo }}'",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			path, err := tc.file.Destination(now)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(err, tc.expectedErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expected, path)
+		})
+	}
+}
+
+func TestFileOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := result.NewResultList(false)
+	rl.AddResult(result.Result{
+		Name:     "a",
+		Status:   result.Fail,
+		Breaches: []breach.Breach{&breach.ValueBreach{Value: "Fail a"}},
+	})
+
+	t.Run("notConfigured", func(t *testing.T) {
+		out, err := (&File{Format: "json"}).Output(&rl)
+		assert.NoError(err)
+		assert.Nil(out)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sub", "results.txt")
+		out, err := (&File{Format: "pretty", Path: path}).Output(&rl)
+		assert.NoError(err)
+		assert.Nil(out)
+		content, err := os.ReadFile(path)
+		assert.NoError(err)
+		assert.Equal("# Breaches were detected\n\n  ### a\n     -- Fail a\n\n", string(content))
+	})
+
+	t.Run("dir", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := (&File{Format: "json", Dir: dir, Filename: "results.{{ .Ext }}"}).Output(&rl)
+		assert.NoError(err)
+		assert.FileExists(filepath.Join(dir, "results.json"))
+	})
+
+	t.Run("unsupportedFormat", func(t *testing.T) {
+		_, err := (&File{Format: "foo", Path: filepath.Join(t.TempDir(), "foo")}).Output(&rl)
+		assert.ErrorContains(err, "unsupported output format 'foo' for file output")
+	})
+}
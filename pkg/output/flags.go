@@ -29,7 +29,8 @@ func (f *Stdout) ValidateOutputFormat() bool {
 
 func (f *Stdout) AddFlags(c *cobra.Command) {
 	c.Flags().StringVarP(&f.Format, "output-format",
-		"o", "pretty", `Output format [pretty|table|json|junit]
+		"o", "pretty", `Output format [pretty|table|json|junit|sarif|markdown|html|
+github|codequality|checkstyle]
 (env: SHIPSHAPE_OUTPUT_FORMAT)`)
 }
 
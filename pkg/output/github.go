@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// GithubActions outputs the breaches as GitHub Actions workflow commands,
+// which annotate the files of a pull request, e.g,
+// "::error file=web/index.php,line=3,title=Check::Breach".
+func (p *Stdout) GithubActions(rl *result.ResultList, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+
+	for _, r := range rl.Results {
+		for _, b := range unresolvedBreaches(r) {
+			params := []string{}
+			if l := b.GetLocation(); l != nil && l.File != "" {
+				params = append(params, "file="+githubProperty(l.File))
+				if l.Line > 0 {
+					params = append(params, fmt.Sprintf("line=%d", l.Line))
+				}
+				if l.EndLine > 0 {
+					params = append(params, fmt.Sprintf("endLine=%d", l.EndLine))
+				}
+				if l.Column > 0 {
+					params = append(params, fmt.Sprintf("col=%d", l.Column))
+				}
+				if l.EndColumn > 0 {
+					params = append(params, fmt.Sprintf("endColumn=%d", l.EndColumn))
+				}
+			}
+			params = append(params, "title="+githubProperty(r.Name))
+			fmt.Fprintf(buf, "::%s %s::%s\n", GithubLevel(breachSeverity(r, b)),
+				strings.Join(params, ","), githubData(b.String()))
+		}
+	}
+}
+
+// GithubLevel maps a severity to a workflow command.
+func GithubLevel(severity string) string {
+	if level := SarifLevel(severity); level != "note" {
+		return level
+	}
+	return "notice"
+}
+
+// githubData escapes a workflow command's message.
+func githubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// githubProperty escapes a workflow command's parameter.
+func githubProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(githubData(s))
+}
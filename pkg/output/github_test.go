@@ -0,0 +1,60 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// ciResultList is used to test the CI formats.
+func ciResultList() result.ResultList {
+	return result.ResultList{
+		Results: []result.Result{
+			{
+				Name:     "Admin, permissions",
+				Severity: "high",
+				Status:   result.Fail,
+				Breaches: []breach.Breach{
+					&breach.ValueBreach{
+						CheckName: "admin",
+						Value:     "50% of\nroles",
+						Location: &breach.Location{
+							File: "config/user.role.yml", Line: 3, EndLine: 4, Column: 5},
+					},
+					&breach.ValueBreach{CheckName: "admin", Severity: "low", Value: "no location"},
+					&breach.ValueBreach{CheckName: "admin", Value: "waived",
+						Waiver: &breach.Waiver{Reason: "ok"}},
+					&breach.ValueBreach{CheckName: "admin", Value: "suppressed", Suppressed: true},
+					&breach.ValueBreach{CheckName: "admin", Value: "remediated",
+						RemediationResult: remediation.RemediationResult{
+							Status: remediation.RemediationStatusSuccess}},
+				},
+			},
+			{Name: "b", Severity: "normal", Status: result.Pass},
+		},
+	}
+}
+
+func TestGithubActions(t *testing.T) {
+	rl := ciResultList()
+	var buf bytes.Buffer
+	(&Stdout{}).GithubActions(&rl, &buf)
+	assert.Equal(t,
+		"::error file=config/user.role.yml,line=3,endLine=4,col=5,title=Admin%2C permissions::50%25 of%0Aroles\n"+
+			"::notice title=Admin%2C permissions::no location\n",
+		buf.String())
+}
+
+func TestGithubLevel(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("notice", GithubLevel("low"))
+	assert.Equal("warning", GithubLevel("normal"))
+	assert.Equal("error", GithubLevel("high"))
+	assert.Equal("error", GithubLevel("critical"))
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// htmlData is the data rendered by the html template.
+type htmlData struct {
+	Status               result.Status
+	TotalBreaches        uint32
+	TotalSuppressed      uint32
+	TotalWaived          uint32
+	RemediationPerformed bool
+	RemediationStatus    remediation.RemediationStatus
+	Severities           []reportCount
+	Types                []reportCount
+	Sections             []reportSection
+}
+
+// htmlTemplate is a standalone document; styles are inlined so the report can
+// be shared as a single file.
+var htmlTemplate = template.Must(template.New("html").Funcs(template.FuncMap{
+	"join": inlineValues,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Shipshape results</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 60em; padding: 0 1em; color: #24292f; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: .3em .6em; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+section { border-top: 1px solid #d0d7de; margin-top: 2em; }
+.status { border-radius: 1em; color: #fff; font-size: .8em; padding: .1em .6em; }
+.status-Pass { background: #1a7f37; }
+.status-Fail { background: #cf222e; }
+summary { cursor: pointer; }
+</style>
+</head>
+<body>
+<h1>Shipshape results</h1>
+{{- if not .Sections }}
+<p>No result available; ensure your shipshape.yml is configured correctly.</p>
+{{- else }}
+{{- if eq .Status "Pass" }}
+<p>Ship is in top shape; no breach detected!</p>
+{{- else }}
+<p>{{ .TotalBreaches }} breach(es) detected.</p>
+{{- end }}
+{{- if or .TotalSuppressed .TotalWaived }}
+<p>{{ .TotalSuppressed }} suppressed, {{ .TotalWaived }} waived.</p>
+{{- end }}
+{{- if .RemediationPerformed }}
+<p>Remediation: {{ .RemediationStatus }}.</p>
+{{- end }}
+{{- if .Severities }}
+<table>
+<tr><th>Severity</th><th>Breaches</th></tr>
+{{- range .Severities }}
+<tr><td>{{ .Key }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Types }}
+<table>
+<tr><th>Check type</th><th>Breaches</th></tr>
+{{- range .Types }}
+<tr><td>{{ .Key }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- range .Sections }}
+<section>
+<h2>{{ .Name }} <span class="status status-{{ .Status }}">{{ .Status }}</span></h2>
+{{- if .Description }}
+<p>{{ .Description }}</p>
+{{- end }}
+{{- if or .Severity .RemediationStatus }}
+<ul>
+{{- if .Severity }}
+<li>Severity: {{ .Severity }}</li>
+{{- end }}
+{{- if .RemediationStatus }}
+<li>Remediation: {{ .RemediationStatus }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Breaches }}
+<h3>Breaches</h3>
+<table>
+<tr><th>Severity</th><th>Breach</th><th>Location</th></tr>
+{{- range .Breaches }}
+<tr><td>{{ .Severity }}</td><td>
+{{- if not .Values }}{{ .Summary }}
+{{- else if .Collapsed }}<details><summary>{{ .Summary }}: {{ len .Values }} values</summary><ul>
+{{- range .Values }}<li>{{ . }}</li>{{ end }}</ul></details>
+{{- else }}{{ .Summary }}: {{ join .Values }}
+{{- end }}</td><td>{{ .Location }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Passes }}
+<h3>Passes</h3>
+<ul>
+{{- range .Passes }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Warnings }}
+<h3>Warnings</h3>
+<ul>
+{{- range .Warnings }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+</section>
+{{- end }}
+{{- end }}
+</body>
+</html>
+`))
+
+// HTML outputs the results as a standalone html document.
+func (p *Stdout) HTML(rl *result.ResultList, w io.Writer) error {
+	data := htmlData{
+		Status:               rl.Status(),
+		TotalBreaches:        rl.TotalBreaches,
+		TotalSuppressed:      rl.TotalSuppressed,
+		TotalWaived:          rl.TotalWaived,
+		RemediationPerformed: rl.RemediationPerformed,
+		Severities:           severityCounts(rl),
+		Types:                sortedCounts(rl.BreachCountByType),
+	}
+	if len(rl.Results) > 0 {
+		data.Sections = reportSections(rl)
+	}
+	if rl.RemediationPerformed {
+		data.RemediationStatus = rl.RemediationStatus()
+	}
+	return htmlTemplate.Execute(w, data)
+}
@@ -0,0 +1,63 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestHTML(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("noResult", func(t *testing.T) {
+		rl := result.NewResultList(false)
+		var buf bytes.Buffer
+		assert.NoError((&Stdout{}).HTML(&rl, &buf))
+		assert.Contains(buf.String(), "<p>No result available; ensure your shipshape.yml is configured correctly.</p>")
+	})
+
+	t.Run("breachesDetected", func(t *testing.T) {
+		rl := result.ResultList{
+			TotalBreaches:         2,
+			BreachCountBySeverity: map[string]int{"high": 2},
+			BreachCountByType:     map[string]int{"yaml": 2},
+			Results: []result.Result{
+				{
+					Name:        "a",
+					Description: "Checks <scripts>.",
+					Severity:    "high",
+					Status:      result.Fail,
+					Breaches: []breach.Breach{
+						&breach.ValueBreach{Value: "<script>alert(1)</script>"},
+						&breach.KeyValuesBreach{
+							Key:    "admin",
+							Values: []string{"a", "b", "c", "d", "e", "f"},
+						},
+					},
+				},
+				{Name: "b", Severity: "normal", Status: result.Pass, Passes: []string{"all good"}},
+			},
+		}
+		var buf bytes.Buffer
+		assert.NoError((&Stdout{}).HTML(&rl, &buf))
+		out := buf.String()
+
+		assert.Contains(out, "<!DOCTYPE html>")
+		assert.Contains(out, "<style>")
+		assert.NotContains(out, "<link")
+		assert.NotContains(out, "src=")
+		assert.Contains(out, "<p>2 breach(es) detected.</p>")
+		assert.Contains(out, "<tr><th>Severity</th><th>Breaches</th></tr>\n<tr><td>high</td><td>2</td></tr>")
+		assert.Contains(out, "<tr><th>Check type</th><th>Breaches</th></tr>\n<tr><td>yaml</td><td>2</td></tr>")
+		assert.Contains(out, `<h2>a <span class="status status-Fail">Fail</span></h2>`)
+		assert.Contains(out, "<p>Checks &lt;scripts&gt;.</p>")
+		assert.Contains(out, "<td>&lt;script&gt;alert(1)&lt;/script&gt;</td>")
+		assert.Contains(out, "<details><summary>admin: 6 values</summary><ul><li>a</li>")
+		assert.Contains(out, "<h3>Passes</h3>\n<ul>\n<li>all good</li>")
+	})
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// JUnitDefaultSuite is the name of the test suite for results without a
+// check type, e.g, the analysers' results.
+const JUnitDefaultSuite = "shipshape"
+
+// JUnit outputs the checks results in the JUnit XML format, with a test suite
+// for each check type and a test case for each check or analyser. Active
+// breaches are failures, while waived and suppressed breaches are skipped.
+func (p *Stdout) JUnit(rl *result.ResultList, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	tss := JUnitTestSuites{TestSuites: []JUnitTestSuite{}}
+
+	suites := map[string]*JUnitTestSuite{}
+	durations := map[string]time.Duration{}
+	var total time.Duration
+	for _, r := range rl.Results {
+		name := r.CheckType
+		if name == "" {
+			name = JUnitDefaultSuite
+		}
+		ts, ok := suites[name]
+		if !ok {
+			ts = &JUnitTestSuite{Name: name, TestCases: []JUnitTestCase{}}
+			suites[name] = ts
+		}
+
+		tc := junitTestCase(name, r)
+		ts.TestCases = append(ts.TestCases, tc)
+		ts.Tests++
+		if len(tc.Failures) > 0 {
+			ts.Failures++
+		} else if len(tc.Skipped) > 0 {
+			ts.Skipped++
+		}
+		durations[name] += r.Duration
+		total += r.Duration
+	}
+
+	names := []string{}
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ts := suites[name]
+		ts.Time = junitTime(durations[name])
+		tss.Tests += ts.Tests
+		tss.Failures += ts.Failures
+		tss.Skipped += ts.Skipped
+		tss.TestSuites = append(tss.TestSuites, *ts)
+	}
+	tss.Time = junitTime(total)
+
+	xmlBytes, err := xml.MarshalIndent(tss, "", "    ")
+	if err != nil {
+		fmt.Fprintf(buf, "error occurred while converting to XML: %s\n", err.Error())
+		buf.Flush()
+		return
+	}
+	fmt.Fprint(buf, xml.Header)
+	fmt.Fprint(buf, string(xmlBytes))
+	fmt.Fprintln(buf)
+	buf.Flush()
+}
+
+func junitTestCase(suite string, r result.Result) JUnitTestCase {
+	tc := JUnitTestCase{
+		Name:       r.Name,
+		ClassName:  suite,
+		Time:       junitTime(r.Duration),
+		Properties: []JUnitProperty{{Name: "severity", Value: r.Severity}},
+	}
+	if r.CheckType != "" {
+		tc.Properties = append(tc.Properties,
+			JUnitProperty{Name: "check-type", Value: r.CheckType})
+	}
+	if r.RemediationStatus != "" {
+		tc.Properties = append(tc.Properties,
+			JUnitProperty{Name: "remediation-status", Value: string(r.RemediationStatus)})
+	}
+
+	for _, b := range r.Breaches {
+		if b.GetRemediationResult().Status == remediation.RemediationStatusSuccess {
+			continue
+		}
+		if w := b.GetWaiver(); w != nil {
+			tc.Skipped = append(tc.Skipped, JUnitSkipped{
+				Message: fmt.Sprintf("waived: %s - %s", b, w)})
+			continue
+		}
+		if b.IsSuppressed() {
+			tc.Skipped = append(tc.Skipped, JUnitSkipped{
+				Message: fmt.Sprintf("suppressed: %s - found in baseline", b)})
+			continue
+		}
+
+		severity := b.GetSeverity()
+		if severity == "" {
+			severity = r.Severity
+		}
+		f := JUnitFailure{Message: b.String(), Type: severity, Text: b.String()}
+		if loc := b.GetLocation(); loc != nil {
+			f.File = loc.File
+			f.Line = loc.Line
+			f.Text += "\nat " + loc.String()
+		}
+		tc.Failures = append(tc.Failures, f)
+	}
+	return tc
+}
+
+// junitTime formats the duration in seconds.
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,156 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestJUnit(t *testing.T) {
+	tt := []struct {
+		name     string
+		rl       result.ResultList
+		expected string
+	}{
+		{
+			name: "noResult",
+			rl:   result.NewResultList(false),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0" errors="0" skipped="0" time="0.000"></testsuites>
+`,
+		},
+		{
+			name: "allPass",
+			rl: result.ResultList{
+				Results: []result.Result{{
+					Name:     "a",
+					Severity: "normal",
+					Status:   result.Pass,
+					Duration: 1500 * time.Millisecond,
+				}}},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="1" failures="0" errors="0" skipped="0" time="1.500">
+    <testsuite name="shipshape" tests="1" failures="0" errors="0" skipped="0" time="1.500">
+        <testcase name="a" classname="shipshape" time="1.500">
+            <properties>
+                <property name="severity" value="normal"></property>
+            </properties>
+        </testcase>
+    </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name: "mixedPassFail",
+			rl: result.ResultList{
+				Results: []result.Result{
+					{Name: "a", Severity: "normal", CheckType: "file", Status: result.Pass},
+					{
+						Name:      "b",
+						Severity:  "high",
+						CheckType: "file",
+						Status:    result.Fail,
+						Duration:  20 * time.Millisecond,
+						Breaches: []breach.Breach{
+							&breach.ValueBreach{Value: "Fail b"},
+							&breach.ValueBreach{
+								Severity: "critical",
+								Value:    "Fail c",
+								Location: &breach.Location{File: "core.extension.yml", Line: 4},
+							},
+						},
+					},
+					{
+						Name:     "c",
+						Severity: "low",
+						Status:   result.Fail,
+						Breaches: []breach.Breach{&breach.ValueBreach{Value: "Fail d"}},
+					},
+				},
+			},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2" errors="0" skipped="0" time="0.020">
+    <testsuite name="file" tests="2" failures="1" errors="0" skipped="0" time="0.020">
+        <testcase name="a" classname="file" time="0.000">
+            <properties>
+                <property name="severity" value="normal"></property>
+                <property name="check-type" value="file"></property>
+            </properties>
+        </testcase>
+        <testcase name="b" classname="file" time="0.020">
+            <properties>
+                <property name="severity" value="high"></property>
+                <property name="check-type" value="file"></property>
+            </properties>
+            <failure message="Fail b" type="high">Fail b</failure>
+            <failure message="Fail c" type="critical" file="core.extension.yml" line="4">Fail c&#xA;at core.extension.yml:4</failure>
+        </testcase>
+    </testsuite>
+    <testsuite name="shipshape" tests="1" failures="1" errors="0" skipped="0" time="0.000">
+        <testcase name="c" classname="shipshape" time="0.000">
+            <properties>
+                <property name="severity" value="low"></property>
+            </properties>
+            <failure message="Fail d" type="low">Fail d</failure>
+        </testcase>
+    </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name: "breachSkipped",
+			rl: result.ResultList{
+				Results: []result.Result{{
+					Name:              "b",
+					Severity:          "normal",
+					Status:            result.Pass,
+					RemediationStatus: remediation.RemediationStatusSuccess,
+					Breaches: []breach.Breach{
+						&breach.ValueBreach{
+							Value:  "Fail b",
+							Waiver: &breach.Waiver{Reason: "SSO enforced"},
+						},
+						&breach.ValueBreach{Value: "Fail c", Suppressed: true},
+						&breach.ValueBreach{
+							Value: "Fail d",
+							RemediationResult: remediation.RemediationResult{
+								Status: remediation.RemediationStatusSuccess,
+							},
+						},
+					},
+				}},
+			},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="1" failures="0" errors="0" skipped="1" time="0.000">
+    <testsuite name="shipshape" tests="1" failures="0" errors="0" skipped="1" time="0.000">
+        <testcase name="b" classname="shipshape" time="0.000">
+            <properties>
+                <property name="severity" value="normal"></property>
+                <property name="remediation-status" value="success"></property>
+            </properties>
+            <skipped message="waived: Fail b - SSO enforced"></skipped>
+            <skipped message="suppressed: Fail c - found in baseline"></skipped>
+        </testcase>
+    </testsuite>
+</testsuites>
+`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			var buf bytes.Buffer
+			s := &Stdout{}
+			s.JUnit(&tc.rl, &buf)
+			assert.Equal(tc.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// Markdown outputs the results as a markdown document, e.g, for a pull
+// request comment or a CI job summary.
+func (p *Stdout) Markdown(rl *result.ResultList, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+
+	fmt.Fprint(buf, "# Shipshape results\n\n")
+	if len(rl.Results) == 0 {
+		fmt.Fprint(buf, "No result available; ensure your shipshape.yml is configured correctly.\n")
+		return
+	}
+
+	if rl.Status() == result.Pass {
+		fmt.Fprint(buf, "Ship is in top shape; no breach detected!\n")
+	} else {
+		fmt.Fprintf(buf, "%d breach(es) detected.\n", rl.TotalBreaches)
+	}
+	if rl.TotalSuppressed > 0 || rl.TotalWaived > 0 {
+		fmt.Fprintf(buf, "\n%d suppressed, %d waived.\n", rl.TotalSuppressed, rl.TotalWaived)
+	}
+	if rl.RemediationPerformed {
+		fmt.Fprintf(buf, "\nRemediation: %s.\n", rl.RemediationStatus())
+	}
+
+	markdownCounts(buf, "Severity", severityCounts(rl))
+	markdownCounts(buf, "Check type", sortedCounts(rl.BreachCountByType))
+
+	for _, s := range reportSections(rl) {
+		fmt.Fprintf(buf, "\n## %s\n\n", s.Name)
+		if s.Description != "" {
+			fmt.Fprintf(buf, "%s\n\n", s.Description)
+		}
+		if s.Severity != "" {
+			fmt.Fprintf(buf, "- Severity: %s\n", s.Severity)
+		}
+		fmt.Fprintf(buf, "- Status: %s\n", s.Status)
+		if s.RemediationStatus != "" {
+			fmt.Fprintf(buf, "- Remediation: %s\n", s.RemediationStatus)
+		}
+
+		if len(s.Breaches) > 0 {
+			fmt.Fprint(buf, "\n### Breaches\n\n")
+			fmt.Fprint(buf, "| Severity | Breach | Location |\n|---|---|---|\n")
+			for _, b := range s.Breaches {
+				fmt.Fprintf(buf, "| %s | %s | %s |\n", b.Severity,
+					markdownBreach(b), markdownCell(b.Location))
+			}
+		}
+		markdownList(buf, "Passes", s.Passes)
+		markdownList(buf, "Warnings", s.Warnings)
+	}
+}
+
+// markdownCounts writes a summary table, if there is any count.
+func markdownCounts(w io.Writer, header string, counts []reportCount) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n| %s | Breaches |\n|---|---|\n", header)
+	for _, c := range counts {
+		fmt.Fprintf(w, "| %s | %d |\n", markdownCell(c.Key), c.Count)
+	}
+}
+
+func markdownList(w io.Writer, header string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n### %s\n\n", header)
+	for _, i := range items {
+		fmt.Fprintf(w, "- %s\n", strings.ReplaceAll(i, "\n", " "))
+	}
+}
+
+// markdownBreach renders the breach for a table cell, collapsing long lists
+// of values in a details element.
+func markdownBreach(b reportBreach) string {
+	if b.Values == nil {
+		return markdownCell(b.Summary)
+	}
+	if !b.Collapsed() {
+		return markdownCell(b.Summary + ": " + inlineValues(b.Values))
+	}
+	values := []string{}
+	for _, v := range b.Values {
+		values = append(values, markdownCell(v))
+	}
+	return fmt.Sprintf("<details><summary>%s: %d values</summary>%s</details>",
+		markdownCell(b.Summary), len(b.Values), strings.Join(values, "<br>"))
+}
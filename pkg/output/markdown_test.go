@@ -0,0 +1,120 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestMarkdown(t *testing.T) {
+	tt := []struct {
+		name     string
+		rl       result.ResultList
+		expected string
+	}{
+		{
+			name: "noResult",
+			rl:   result.NewResultList(false),
+			expected: "# Shipshape results\n\n" +
+				"No result available; ensure your shipshape.yml is configured correctly.\n",
+		},
+		{
+			name: "topShape",
+			rl: result.ResultList{
+				Results: []result.Result{{
+					Name:     "a",
+					Severity: "normal",
+					Status:   result.Pass,
+					Passes:   []string{"all good"},
+				}},
+			},
+			expected: "# Shipshape results\n\nShip is in top shape; no breach detected!\n\n" +
+				"## a\n\n- Severity: normal\n- Status: Pass\n\n### Passes\n\n- all good\n",
+		},
+		{
+			name: "breachesDetected",
+			rl: result.ResultList{
+				TotalBreaches:         3,
+				TotalWaived:           1,
+				BreachCountBySeverity: map[string]int{"normal": 2, "high": 1},
+				BreachCountByType:     map[string]int{"yaml": 3},
+				Results: []result.Result{
+					{
+						Name:        "Admin permissions",
+						Description: "Admin permissions",
+						Severity:    "high",
+						Status:      result.Fail,
+						Breaches: []breach.Breach{
+							&breach.ValueBreach{Value: "Fail a | b"},
+							&breach.ValueBreach{Value: "Fail c", Waiver: &breach.Waiver{Reason: "ok"}},
+						},
+					},
+					{
+						Name:        "b",
+						Description: "Checks the roles' permissions.",
+						Severity:    "normal",
+						Status:      result.Fail,
+						Breaches: []breach.Breach{
+							&breach.ValueBreach{
+								Severity: "low",
+								Value:    "Fail b",
+								Location: &breach.Location{File: "web/index.php", Line: 3},
+							},
+							&breach.KeyValuesBreach{
+								KeyLabel:   "role",
+								Key:        "editor",
+								ValueLabel: "permissions",
+								Values:     []string{"a", "b"},
+							},
+							&breach.KeyValuesBreach{
+								Key:    "admin",
+								Values: []string{"a", "b", "c", "d", "e", "f"},
+							},
+						},
+						Warnings: []string{"waiver expired"},
+					},
+				},
+			},
+			expected: "# Shipshape results\n\n3 breach(es) detected.\n\n0 suppressed, 1 waived.\n\n" +
+				"| Severity | Breaches |\n|---|---|\n| high | 1 |\n| normal | 2 |\n\n" +
+				"| Check type | Breaches |\n|---|---|\n| yaml | 3 |\n\n" +
+				"## Admin permissions\n\n- Severity: high\n- Status: Fail\n\n" +
+				"### Breaches\n\n| Severity | Breach | Location |\n|---|---|---|\n" +
+				"| high | Fail a \\| b |  |\n\n" +
+				"## b\n\nChecks the roles' permissions.\n\n- Severity: normal\n- Status: Fail\n\n" +
+				"### Breaches\n\n| Severity | Breach | Location |\n|---|---|---|\n" +
+				"| low | Fail b | web/index.php:3 |\n" +
+				"| normal | [role:editor] permissions: a, b |  |\n" +
+				"| normal | <details><summary>admin: 6 values</summary>a<br>b<br>c<br>d<br>e<br>f</details> |  |\n\n" +
+				"### Warnings\n\n- waiver expired\n",
+		},
+		{
+			name: "remediation",
+			rl: result.ResultList{
+				RemediationPerformed: true,
+				Results: []result.Result{{
+					Name:              "a",
+					Status:            result.Pass,
+					RemediationStatus: remediation.RemediationStatusSuccess,
+				}},
+			},
+			expected: "# Shipshape results\n\nShip is in top shape; no breach detected!\n\n" +
+				"Remediation: success.\n\n## a\n\n- Status: Pass\n- Remediation: success\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			s := &Stdout{}
+			s.Markdown(&tc.rl, &buf)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
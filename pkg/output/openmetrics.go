@@ -0,0 +1,192 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// OpenMetrics writes metrics about the results to a file, in the text format
+// read by node_exporter's textfile collector.
+type OpenMetrics struct {
+	// Plugin-specific fields.
+	// Path is the file to write to, e.g,
+	// "/var/lib/node_exporter/textfile/shipshape.prom".
+	Path string `yaml:"path"`
+	// Labels are added to every metric, e.g, to identify the environment.
+	// Their names must match [a-zA-Z_][a-zA-Z0-9_]*.
+	Labels map[string]string `yaml:"labels"`
+}
+
+var metricLabelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+var om = &OpenMetrics{}
+
+func init() {
+	Outputters["openmetrics"] = om
+	Factories["openmetrics"] = func() Outputter { return &OpenMetrics{} }
+}
+
+// Output writes the metrics to the file, if configured, returning no output.
+// The file is replaced atomically so the collector never reads a partial
+// file.
+func (p *OpenMetrics) Output(rl *result.ResultList) ([]byte, error) {
+	if p.Path == "" {
+		log.Debug("skipping openmetrics output, no path configured")
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if err := p.Metrics(rl, time.Now(), &buf); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(p.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for '%s': %w", p.Path, err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(p.Path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write metrics to '%s': %w", p.Path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write metrics to '%s': %w", p.Path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write metrics to '%s': %w", p.Path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write metrics to '%s': %w", p.Path, err)
+	}
+	if err := os.Rename(tmp.Name(), p.Path); err != nil {
+		return nil, fmt.Errorf("failed to write metrics to '%s': %w", p.Path, err)
+	}
+	log.WithField("path", p.Path).Info("wrote metrics to file")
+	return nil, nil
+}
+
+// Metrics writes the metrics for the results, as of the given time. Nothing
+// is written if a configured label's name is invalid.
+func (p *OpenMetrics) Metrics(rl *result.ResultList, now time.Time, w io.Writer) error {
+	for k := range p.Labels {
+		if !metricLabelNameRegex.MatchString(k) {
+			return fmt.Errorf("invalid openmetrics label name '%s', expected it to match %s",
+				k, metricLabelNameRegex.String())
+		}
+	}
+
+	metricHeader(w, "shipshape_breaches_total", "Number of active breaches.")
+	for _, r := range rl.Results {
+		counts := map[string]int{}
+		for _, b := range r.ActiveBreaches() {
+			counts[breachSeverity(r, b)]++
+		}
+		// Analysers without breaches report 0 rather than no sample, so that
+		// alerts on their breaches resolve once fixed.
+		if len(counts) == 0 {
+			counts[r.Severity] = 0
+		}
+		severities := []string{}
+		for s := range counts {
+			severities = append(severities, s)
+		}
+		sort.Slice(severities, func(i, j int) bool {
+			return config.Severity(severities[i]).Level() > config.Severity(severities[j]).Level()
+		})
+		for _, s := range severities {
+			p.sample(w, "shipshape_breaches_total", counts[s],
+				"severity", s, "check_type", r.CheckType, "analyser", metricAnalyser(r),
+				"description", r.Name)
+		}
+	}
+
+	metricHeader(w, "shipshape_check_status", "Status of the check or analyser; 1 if it passed, 0 if it failed.")
+	for _, r := range rl.Results {
+		status := 0
+		if r.Status == result.Pass {
+			status = 1
+		}
+		p.sample(w, "shipshape_check_status", status,
+			"severity", r.Severity, "check_type", r.CheckType, "analyser", metricAnalyser(r),
+			"description", r.Name)
+	}
+
+	if rl.RemediationPerformed {
+		metricHeader(w, "shipshape_remediation_total", "Number of breaches by remediation status.")
+		statuses := []string{}
+		for s := range rl.RemediationTotals {
+			statuses = append(statuses, s)
+		}
+		sort.Strings(statuses)
+		for _, s := range statuses {
+			p.sample(w, "shipshape_remediation_total", rl.RemediationTotals[s], "status", s)
+		}
+	}
+
+	metricHeader(w, "shipshape_check_duration_seconds", "Time taken to run the check or analyser.")
+	var total time.Duration
+	for _, r := range rl.Results {
+		total += r.Duration
+		p.sample(w, "shipshape_check_duration_seconds", r.Duration.Seconds(),
+			"check_type", r.CheckType, "analyser", metricAnalyser(r), "description", r.Name)
+	}
+	metricHeader(w, "shipshape_run_duration_seconds", "Total time taken to run the checks and analysers.")
+	p.sample(w, "shipshape_run_duration_seconds", total.Seconds())
+	metricHeader(w, "shipshape_last_run_timestamp_seconds", "Time of the last run.")
+	p.sample(w, "shipshape_last_run_timestamp_seconds", now.Unix())
+	return nil
+}
+
+// metricAnalyser returns the analyser label of a result: the analyser's id,
+// since several analysers may share a description, or the check's name.
+func metricAnalyser(r result.Result) string {
+	if r.Analyser != "" {
+		return r.Analyser
+	}
+	return r.Name
+}
+
+func metricHeader(w io.Writer, name string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// sample writes a metric's value, with the configured labels and the given
+// label names and values.
+func (p *OpenMetrics) sample(w io.Writer, name string, value any, labels ...string) {
+	names := []string{}
+	for k := range p.Labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		labels = append(labels, k, p.Labels[k])
+	}
+
+	pairs := []string{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], metricLabelValue(labels[i+1])))
+	}
+	if len(pairs) == 0 {
+		fmt.Fprintf(w, "%s %v\n", name, value)
+		return
+	}
+	fmt.Fprintf(w, "%s{%s} %v\n", name, strings.Join(pairs, ","), value)
+}
+
+// metricLabelValue escapes a label's value.
+func metricLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
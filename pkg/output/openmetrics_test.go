@@ -0,0 +1,121 @@
+package output_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func metricsResultList() result.ResultList {
+	return result.ResultList{
+		RemediationPerformed: true,
+		RemediationTotals:    map[string]uint32{"successful": 1, "failed": 2},
+		Results: []result.Result{
+			{
+				Name:      "a",
+				Severity:  "high",
+				CheckType: "yaml",
+				Status:    result.Fail,
+				Duration:  1500 * time.Millisecond,
+				Breaches: []breach.Breach{
+					&breach.ValueBreach{Value: "Fail a"},
+					&breach.ValueBreach{Value: "Fail b"},
+					&breach.ValueBreach{Value: "Fail c", Severity: "critical"},
+					&breach.ValueBreach{Value: "waived", Waiver: &breach.Waiver{Reason: "ok"}},
+				},
+			},
+			{
+				Name:     `b "quoted"`,
+				Severity: "normal",
+				Status:   result.Pass,
+				Duration: 500 * time.Millisecond,
+			},
+		},
+	}
+}
+
+func TestOpenMetricsMetrics(t *testing.T) {
+	rl := metricsResultList()
+	var buf bytes.Buffer
+	err := (&OpenMetrics{Labels: map[string]string{"env": "prod"}}).
+		Metrics(&rl, time.Unix(1700000000, 0), &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP shipshape_breaches_total Number of active breaches.
+# TYPE shipshape_breaches_total gauge
+shipshape_breaches_total{severity="critical",check_type="yaml",analyser="a",description="a",env="prod"} 1
+shipshape_breaches_total{severity="high",check_type="yaml",analyser="a",description="a",env="prod"} 2
+shipshape_breaches_total{severity="normal",check_type="",analyser="b \"quoted\"",description="b \"quoted\"",env="prod"} 0
+# HELP shipshape_check_status Status of the check or analyser; 1 if it passed, 0 if it failed.
+# TYPE shipshape_check_status gauge
+shipshape_check_status{severity="high",check_type="yaml",analyser="a",description="a",env="prod"} 0
+shipshape_check_status{severity="normal",check_type="",analyser="b \"quoted\"",description="b \"quoted\"",env="prod"} 1
+# HELP shipshape_remediation_total Number of breaches by remediation status.
+# TYPE shipshape_remediation_total gauge
+shipshape_remediation_total{status="failed",env="prod"} 2
+shipshape_remediation_total{status="successful",env="prod"} 1
+# HELP shipshape_check_duration_seconds Time taken to run the check or analyser.
+# TYPE shipshape_check_duration_seconds gauge
+shipshape_check_duration_seconds{check_type="yaml",analyser="a",description="a",env="prod"} 1.5
+shipshape_check_duration_seconds{check_type="",analyser="b \"quoted\"",description="b \"quoted\"",env="prod"} 0.5
+# HELP shipshape_run_duration_seconds Total time taken to run the checks and analysers.
+# TYPE shipshape_run_duration_seconds gauge
+shipshape_run_duration_seconds{env="prod"} 2
+# HELP shipshape_last_run_timestamp_seconds Time of the last run.
+# TYPE shipshape_last_run_timestamp_seconds gauge
+shipshape_last_run_timestamp_seconds{env="prod"} 1700000000
+`, buf.String())
+}
+
+func TestOpenMetricsSharedDescription(t *testing.T) {
+	// Analysers sharing a description, e.g, migrated from the same check.
+	rl := result.ResultList{Results: []result.Result{
+		{Analyser: "modules-devel", Name: "Disallowed modules", Severity: "high", Status: result.Pass},
+		{Analyser: "modules-kint", Name: "Disallowed modules", Severity: "high", Status: result.Pass},
+	}}
+	var buf bytes.Buffer
+	assert.NoError(t, (&OpenMetrics{}).Metrics(&rl, time.Unix(1700000000, 0), &buf))
+	assert.Contains(t, buf.String(), `shipshape_check_status{severity="high",check_type="",analyser="modules-devel",description="Disallowed modules"} 1
+shipshape_check_status{severity="high",check_type="",analyser="modules-kint",description="Disallowed modules"} 1
+`)
+}
+
+func TestOpenMetricsInvalidLabel(t *testing.T) {
+	assert := assert.New(t)
+	rl := metricsResultList()
+	path := filepath.Join(t.TempDir(), "shipshape.prom")
+
+	_, err := (&OpenMetrics{Path: path, Labels: map[string]string{"env-name": "prod"}}).Output(&rl)
+	assert.EqualError(err, "invalid openmetrics label name 'env-name', expected it to match ^[a-zA-Z_][a-zA-Z0-9_]*$")
+	assert.NoFileExists(path)
+}
+
+func TestOpenMetricsOutput(t *testing.T) {
+	assert := assert.New(t)
+	rl := metricsResultList()
+
+	out, err := (&OpenMetrics{}).Output(&rl)
+	assert.NoError(err)
+	assert.Nil(out)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "textfile", "shipshape.prom")
+	out, err = (&OpenMetrics{Path: path}).Output(&rl)
+	assert.NoError(err)
+	assert.Nil(out)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Contains(string(data), "shipshape_check_status{severity=\"high\",check_type=\"yaml\",analyser=\"a\",description=\"a\"} 0\n")
+	assert.Contains(string(data), "shipshape_run_duration_seconds 2\n")
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(entries, 1, "temporary file should be removed")
+}
@@ -1,6 +1,8 @@
 package output
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"sort"
 
@@ -14,8 +16,18 @@ type Outputter interface {
 	Output(*result.ResultList) ([]byte, error)
 }
 
+// Outputters are the default instances of the output plugins, configured
+// using flags or a map in the config.
 var Outputters = map[string]Outputter{}
 
+// Factories create new instances of the output plugins, for each outputter
+// in a list in the config.
+var Factories = map[string]func() Outputter{}
+
+// Configured is the list of outputters from the config; when set, they are
+// used instead of the default Outputters.
+var Configured []Outputter
+
 func RegistryKeys() []string {
 	keys := []string{}
 	for k := range Outputters {
@@ -25,36 +37,78 @@ func RegistryKeys() []string {
 	return keys
 }
 
-func ParseConfig(raw map[string]interface{}, rl *result.ResultList) {
-	count := 0
+// ParseConfig configures the outputters, given either a map of plugin names
+// to their config, which configures the default Outputters, or a list of such
+// single-key maps, which creates a new outputter for each item.
+func ParseConfig(raw interface{}, rl *result.ResultList) {
+	Configured = nil
 	log.WithField("registry", RegistryKeys()).Debug("outputters")
-	for pluginName, pluginMap := range raw {
-		o, ok := Outputters[pluginName]
-		if !ok {
-			continue
-		}
 
-		// Convert the map to yaml, then parse it into the plugin.
-		// Not catching any errors here since the yaml content is known.
-		pluginYaml, _ := yaml.Marshal(pluginMap)
-		yaml.Unmarshal(pluginYaml, o)
+	switch raw := raw.(type) {
+	case map[string]interface{}:
+		count := 0
+		for pluginName, pluginMap := range raw {
+			o, ok := Outputters[pluginName]
+			if !ok {
+				continue
+			}
+			parsePluginConfig(o, pluginMap)
+			log.WithFields(log.Fields{"plugin": pluginName}).Debug("parsed outputter")
+			count++
+		}
+		log.Infof("parsed %d outputters", count)
 
-		log.WithFields(log.Fields{"plugin": pluginName}).Debug("parsed outputter")
-		count++
+	case []interface{}:
+		Configured = []Outputter{}
+		for _, item := range raw {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for pluginName, pluginMap := range itemMap {
+				factory, ok := Factories[pluginName]
+				if !ok {
+					log.WithField("plugin", pluginName).Warn("unknown outputter")
+					continue
+				}
+				o := factory()
+				parsePluginConfig(o, pluginMap)
+				Configured = append(Configured, o)
+				log.WithFields(log.Fields{"plugin": pluginName}).Debug("parsed outputter")
+			}
+		}
+		log.Infof("parsed %d outputters", len(Configured))
 	}
-	log.Infof("parsed %d outputters", count)
 }
 
+// parsePluginConfig converts the map to yaml, then parses it into the plugin.
+// Not catching any errors here since the yaml content is known.
+func parsePluginConfig(o Outputter, pluginMap interface{}) {
+	pluginYaml, _ := yaml.Marshal(pluginMap)
+	yaml.Unmarshal(pluginYaml, o)
+}
+
+// OutputAll runs every outputter, writing their output to w. Outputters run
+// independently: the errors are returned once all of them have run.
 func OutputAll(rl *result.ResultList, w io.Writer) error {
-	for _, p := range Outputters {
+	outputters := Configured
+	if outputters == nil {
+		for _, k := range RegistryKeys() {
+			outputters = append(outputters, Outputters[k])
+		}
+	}
+
+	errs := []error{}
+	for _, p := range outputters {
 		buf, err := p.Output(rl)
 		if err != nil {
-			return err
+			errs = append(errs, err)
+			continue
 		}
 
 		if _, err := w.Write(buf); err != nil {
-			return err
+			errs = append(errs, fmt.Errorf("failed to write output: %w", err))
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
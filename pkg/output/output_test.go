@@ -0,0 +1,90 @@
+package output_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+type testOutputter struct {
+	Message string `yaml:"message"`
+	Fail    bool   `yaml:"fail"`
+}
+
+func (p *testOutputter) Output(rl *result.ResultList) ([]byte, error) {
+	if p.Fail {
+		return nil, errors.New("failed: " + p.Message)
+	}
+	return []byte(p.Message + "\n"), nil
+}
+
+func TestParseConfig(t *testing.T) {
+	currOutputters, currFactories := Outputters, Factories
+	defer func() {
+		Outputters, Factories = currOutputters, currFactories
+		Configured = nil
+	}()
+
+	t.Run("map", func(t *testing.T) {
+		assert := assert.New(t)
+		o := &testOutputter{}
+		Outputters = map[string]Outputter{"test": o}
+
+		var raw interface{}
+		assert.NoError(yaml.Unmarshal([]byte(`{test: {message: foo}, unknown: {}}`), &raw))
+		ParseConfig(raw, nil)
+		assert.Nil(Configured)
+		assert.Equal("foo", o.Message)
+
+		var buf bytes.Buffer
+		assert.NoError(OutputAll(&result.ResultList{}, &buf))
+		assert.Equal("foo\n", buf.String())
+	})
+
+	t.Run("list", func(t *testing.T) {
+		assert := assert.New(t)
+		Outputters = map[string]Outputter{"test": &testOutputter{Message: "default"}}
+		Factories = map[string]func() Outputter{
+			"test": func() Outputter { return &testOutputter{} },
+		}
+
+		var raw interface{}
+		assert.NoError(yaml.Unmarshal([]byte(`
+- test:
+    message: foo
+- unknown: {}
+- test:
+    message: bar
+`), &raw))
+		ParseConfig(raw, nil)
+		assert.Equal([]Outputter{
+			&testOutputter{Message: "foo"},
+			&testOutputter{Message: "bar"},
+		}, Configured)
+
+		var buf bytes.Buffer
+		assert.NoError(OutputAll(&result.ResultList{}, &buf))
+		assert.Equal("foo\nbar\n", buf.String())
+	})
+}
+
+func TestOutputAllIndependent(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { Configured = nil }()
+
+	Configured = []Outputter{
+		&testOutputter{Message: "foo", Fail: true},
+		&testOutputter{Message: "bar"},
+		&testOutputter{Message: "baz", Fail: true},
+	}
+	var buf bytes.Buffer
+	err := OutputAll(&result.ResultList{}, &buf)
+	assert.EqualError(err, "failed: foo\nfailed: baz")
+	assert.Equal("bar\n", buf.String())
+}
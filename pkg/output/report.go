@@ -0,0 +1,148 @@
+package output
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// DetailsThreshold is the number of values above which a key-values breach's
+// values are collapsed in the markdown and html reports.
+const DetailsThreshold = 5
+
+// ProjectRootPath is the path of breaches without a location, for the formats
+// which require one.
+const ProjectRootPath = "."
+
+// reportCount is a row of a report's summary tables.
+type reportCount struct {
+	Key   string
+	Count int
+}
+
+// reportBreach is a breach as displayed in the reports; key-values breaches
+// have their values listed separately from their summary.
+type reportBreach struct {
+	Severity string
+	Summary  string
+	Values   []string
+	Location string
+}
+
+// Collapsed determines whether the breach's values should be collapsed.
+func (b reportBreach) Collapsed() bool {
+	return len(b.Values) > DetailsThreshold
+}
+
+// reportSection is an analyser's section of the reports.
+type reportSection struct {
+	Name              string
+	Description       string
+	Severity          string
+	Status            result.Status
+	RemediationStatus string
+	Breaches          []reportBreach
+	Passes            []string
+	Warnings          []string
+}
+
+// severityCounts returns the breach counts by severity, most severe first.
+func severityCounts(rl *result.ResultList) []reportCount {
+	counts := sortedCounts(rl.BreachCountBySeverity)
+	sort.SliceStable(counts, func(i, j int) bool {
+		return config.Severity(counts[i].Key).Level() > config.Severity(counts[j].Key).Level()
+	})
+	return counts
+}
+
+// sortedCounts returns the non-zero counts, sorted by key. Counts without a
+// key, e.g, the type of analysers' results, are skipped.
+func sortedCounts(m map[string]int) []reportCount {
+	counts := []reportCount{}
+	for k, c := range m {
+		if k == "" || c == 0 {
+			continue
+		}
+		counts = append(counts, reportCount{Key: k, Count: c})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Key < counts[j].Key })
+	return counts
+}
+
+// reportSections builds the analysers' sections, using the results' active
+// breaches only.
+func reportSections(rl *result.ResultList) []reportSection {
+	sections := []reportSection{}
+	for _, r := range rl.Results {
+		s := reportSection{
+			Name:              r.Name,
+			Severity:          r.Severity,
+			Status:            r.Status,
+			RemediationStatus: string(r.RemediationStatus),
+			Passes:            r.Passes,
+			Warnings:          r.Warnings,
+		}
+		if r.Description != r.Name {
+			s.Description = r.Description
+		}
+		for _, b := range r.ActiveBreaches() {
+			s.Breaches = append(s.Breaches, newReportBreach(r, b))
+		}
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+func newReportBreach(r result.Result, b breach.Breach) reportBreach {
+	rb := reportBreach{Severity: breachSeverity(r, b), Summary: b.String()}
+	if l := b.GetLocation(); l != nil {
+		rb.Location = l.String()
+	}
+	if kvs, ok := b.(*breach.KeyValuesBreach); ok {
+		rb.Summary = kvs.Key
+		if kvs.KeyLabel != "" && kvs.ValueLabel != "" {
+			rb.Summary = "[" + kvs.KeyLabel + ":" + kvs.Key + "] " + kvs.ValueLabel
+		}
+		rb.Values = kvs.Values
+	}
+	return rb
+}
+
+// breachSeverity returns the breach's severity, defaulting to the result's.
+func breachSeverity(r result.Result, b breach.Breach) string {
+	if s := b.GetSeverity(); s != "" {
+		return s
+	}
+	return r.Severity
+}
+
+// breachCheckName returns the name of the check or analyser the breach is
+// from, defaulting to the result's name.
+func breachCheckName(r result.Result, b breach.Breach) string {
+	if n := b.GetCheckName(); n != "" {
+		return n
+	}
+	return r.Name
+}
+
+// unresolvedBreaches returns the result's active breaches which were not
+// remediated.
+func unresolvedBreaches(r result.Result) []breach.Breach {
+	breaches := []breach.Breach{}
+	for _, b := range r.ActiveBreaches() {
+		if b.GetRemediationResult().Status == remediation.RemediationStatusSuccess {
+			continue
+		}
+		breaches = append(breaches, b)
+	}
+	return breaches
+}
+
+// inlineValues joins the values of a breach that is not collapsed.
+func inlineValues(values []string) string {
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Retry calls fn until it succeeds, fails with an error which can't be
+// retried, or has been retried the given number of times, returning its last
+// error. The delay before the first retry is backoff, doubled for each of the
+// following ones; what describes the operation in the logs.
+func Retry(what string, retries int, backoff time.Duration, fn func() (retry bool, err error)) error {
+	for attempt := 0; ; attempt++ {
+		retry, err := fn()
+		if err == nil || !retry || attempt >= retries {
+			return err
+		}
+		delay := backoff << attempt
+		log.WithError(err).WithField("delay", delay).Warn(what + " failed, retrying")
+		time.Sleep(delay)
+	}
+}
+
+// ParseDuration parses the duration, if set, returning def otherwise.
+func ParseDuration(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,53 @@
+package output_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+)
+
+func TestRetry(t *testing.T) {
+	origOutput := logrus.StandardLogger().Out
+	logrus.SetOutput(io.Discard)
+	defer logrus.SetOutput(origOutput)
+
+	tt := []struct {
+		name          string
+		retries       int
+		failures      int
+		retry         bool
+		expectCalls   int
+		expectFailure bool
+	}{
+		{name: "success", retries: 2, expectCalls: 1},
+		{name: "retried", retries: 2, failures: 2, retry: true, expectCalls: 3},
+		{name: "exhausted", retries: 2, failures: 3, retry: true, expectCalls: 3, expectFailure: true},
+		{name: "notRetryable", retries: 2, failures: 1, expectCalls: 1, expectFailure: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			calls := 0
+			err := Retry("test", tc.retries, time.Millisecond, func() (bool, error) {
+				calls++
+				if calls <= tc.failures {
+					return tc.retry, errors.New("failed")
+				}
+				return false, nil
+			})
+			assert.Equal(tc.expectCalls, calls)
+			if tc.expectFailure {
+				assert.EqualError(err, "failed")
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+const (
+	SarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	SarifVersion = "2.1.0"
+	// SarifSrcRoot is the base id for the locations inside the project
+	// directory.
+	SarifSrcRoot = "%SRCROOT%"
+)
+
+// SarifLevel maps a severity to a SARIF level.
+func SarifLevel(severity string) string {
+	switch config.Severity(severity) {
+	case config.LowSeverity:
+		return "note"
+	case config.HighSeverity, config.CriticalSeverity:
+		return "error"
+	}
+	return "warning"
+}
+
+// Sarif outputs the results in the SARIF format, with a rule for each check
+// or analyser and a result for each breach. Analysers' rules are identified
+// by the analyser's id, which is stable across changes to its description.
+func (p *Stdout) Sarif(rl *result.ResultList, w io.Writer) {
+	buf := bufio.NewWriter(w)
+	run := SarifRun{
+		Tool: SarifTool{Driver: SarifDriver{
+			Name:           "shipshape",
+			InformationUri: "https://github.com/salsadigitalauorg/shipshape",
+			Rules:          []SarifRule{},
+		}},
+		Results: []SarifResult{},
+	}
+	ruleIndexes := map[string]int{}
+	if projectDir, err := filepath.Abs(config.ProjectDir); err == nil {
+		run.OriginalUriBaseIds = map[string]SarifArtifactLocation{
+			SarifSrcRoot: {Uri: fileUri(projectDir) + "/"},
+		}
+	}
+
+	for _, r := range rl.Results {
+		ruleId, ruleName := r.Name, r.Name
+		if r.Analyser != "" {
+			ruleId, ruleName = r.Analyser, r.Analyser
+		} else if r.CheckType != "" {
+			ruleId = r.CheckType + "/" + r.Name
+		}
+		ruleIndex, ok := ruleIndexes[ruleId]
+		if !ok {
+			rule := SarifRule{
+				Id:                   ruleId,
+				Name:                 ruleName,
+				ShortDescription:     SarifMessage{Text: r.Name},
+				DefaultConfiguration: SarifConfiguration{Level: SarifLevel(r.Severity)},
+				Properties:           map[string]string{"severity": r.Severity},
+			}
+			if r.CheckType != "" {
+				rule.Properties["check-type"] = r.CheckType
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+			ruleIndex = len(run.Tool.Driver.Rules) - 1
+			ruleIndexes[ruleId] = ruleIndex
+		}
+
+		for _, b := range r.Breaches {
+			if b.GetRemediationResult().Status == remediation.RemediationStatusSuccess {
+				continue
+			}
+			severity := b.GetSeverity()
+			if severity == "" {
+				severity = r.Severity
+			}
+			sr := SarifResult{
+				RuleId:    ruleId,
+				RuleIndex: ruleIndex,
+				Level:     SarifLevel(severity),
+				Message:   SarifMessage{Text: b.String()},
+				Locations: breachLocations(b),
+			}
+			if b.IsSuppressed() {
+				sr.Suppressions = []SarifSuppression{{
+					Kind: "external", Justification: "found in baseline"}}
+			} else if w := b.GetWaiver(); w != nil {
+				sr.Suppressions = []SarifSuppression{{
+					Kind: "external", Justification: w.String()}}
+			}
+			run.Results = append(run.Results, sr)
+		}
+	}
+
+	data, err := json.MarshalIndent(SarifLog{
+		Schema:  SarifSchema,
+		Version: SarifVersion,
+		Runs:    []SarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		fmt.Fprintf(buf, "error occurred while converting to SARIF: %s\n", err.Error())
+		buf.Flush()
+		return
+	}
+	fmt.Fprintln(buf, string(data))
+	buf.Flush()
+}
+
+// phpstanLineRegex matches the messages of the phpstan check.
+var phpstanLineRegex = regexp.MustCompile(`^line (\d+): `)
+
+// breachLocations determines the files a breach relates to, using its
+// location if set, or else the breach's key or values:
+//   - the 'file: <path>' key of phpstan breaches, with a location for the
+//     line of each message
+//   - the 'config' key label of yaml breaches
+//   - any key or value which is an existing file.
+//
+// Breaches with none of these are located at the project's root, since code
+// scanning services reject results without a location.
+func breachLocations(b breach.Breach) []SarifLocation {
+	if loc := b.GetLocation(); loc != nil && loc.File != "" {
+		var region *SarifRegion
+		if loc.Line > 0 {
+			region = &SarifRegion{
+				StartLine:   loc.Line,
+				StartColumn: loc.Column,
+				EndLine:     loc.EndLine,
+				EndColumn:   loc.EndColumn,
+			}
+		}
+		return []SarifLocation{newSarifLocation(loc.File, region)}
+	}
+
+	key := breach.BreachGetKey(b)
+	keyLabel := breach.BreachGetKeyLabel(b)
+	values := breach.BreachGetValues(b)
+
+	if f, ok := strings.CutPrefix(key, "file: "); ok {
+		locations := []SarifLocation{}
+		for _, v := range values {
+			match := phpstanLineRegex.FindStringSubmatch(v)
+			if match == nil {
+				continue
+			}
+			line, _ := strconv.Atoi(match[1])
+			locations = append(locations, newSarifLocation(f, &SarifRegion{StartLine: line}))
+		}
+		if len(locations) == 0 {
+			locations = append(locations, newSarifLocation(f, nil))
+		}
+		return locations
+	}
+
+	if f, ok := strings.CutPrefix(keyLabel, "config:"); ok {
+		return []SarifLocation{newSarifLocation(f, nil)}
+	}
+	if keyLabel == "config" && key != "" {
+		return []SarifLocation{newSarifLocation(key, nil)}
+	}
+
+	candidates := append([]string{key, breach.BreachGetValue(b)}, values...)
+	var locations []SarifLocation
+	for _, c := range candidates {
+		if isFile(c) {
+			locations = append(locations, newSarifLocation(c, nil))
+		}
+	}
+	if len(locations) == 0 {
+		locations = append(locations, newSarifLocation(ProjectRootPath, nil))
+	}
+	return locations
+}
+
+// newSarifLocation creates a location for a file, relative to the project
+// directory if it is inside it.
+func newSarifLocation(path string, region *SarifRegion) SarifLocation {
+	loc := SarifLocation{PhysicalLocation: SarifPhysicalLocation{Region: region}}
+
+	absPath := resolvePath(path)
+	projectDir, err := filepath.Abs(config.ProjectDir)
+	if err == nil {
+		rel, err := filepath.Rel(projectDir, absPath)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			loc.PhysicalLocation.ArtifactLocation = SarifArtifactLocation{
+				Uri:       (&url.URL{Path: filepath.ToSlash(rel)}).String(),
+				UriBaseId: SarifSrcRoot,
+			}
+			return loc
+		}
+	}
+	loc.PhysicalLocation.ArtifactLocation = SarifArtifactLocation{Uri: fileUri(absPath)}
+	return loc
+}
+
+// resolvePath returns the absolute path of a file, relative paths being
+// relative to the project directory.
+func resolvePath(path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(config.ProjectDir, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func isFile(path string) bool {
+	if path == "" || strings.ContainsAny(path, "\n") {
+		return false
+	}
+	info, err := os.Stat(resolvePath(path))
+	return err == nil && !info.IsDir()
+}
+
+func fileUri(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,283 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestSarifLevel(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("note", SarifLevel("low"))
+	assert.Equal("warning", SarifLevel("normal"))
+	assert.Equal("warning", SarifLevel(""))
+	assert.Equal("error", SarifLevel("high"))
+	assert.Equal("error", SarifLevel("critical"))
+}
+
+func TestSarif(t *testing.T) {
+	assert := assert.New(t)
+
+	currProjectDir := config.ProjectDir
+	defer func() { config.ProjectDir = currProjectDir }()
+	config.ProjectDir = t.TempDir()
+	if err := os.MkdirAll(filepath.Join(config.ProjectDir, "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	phpinfo := filepath.Join(config.ProjectDir, "web", "phpinfo.php")
+	if err := os.WriteFile(phpinfo, []byte("<?php"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rl := result.ResultList{Results: []result.Result{
+		{Name: "passing", Severity: "normal", Status: result.Pass},
+		{
+			Name:     "illegal-files",
+			Severity: "high",
+			Status:   result.Fail,
+			Breaches: []breach.Breach{
+				&breach.ValueBreach{Severity: "high", ValueLabel: "disallowed value found", Value: phpinfo},
+				&breach.ValueBreach{Value: "not a file"},
+				&breach.ValueBreach{
+					Value: "fixed",
+					RemediationResult: remediation.RemediationResult{
+						Status: remediation.RemediationStatusSuccess},
+				},
+			},
+		},
+		{
+			Name:      "Views cache",
+			CheckType: "yaml",
+			Severity:  "low",
+			Status:    result.Fail,
+			Breaches: []breach.Breach{&breach.KeyValueBreach{
+				Severity:      "low",
+				KeyLabel:      "config:config/views.yml",
+				Key:           "cache.type",
+				ValueLabel:    "actual",
+				Value:         "none",
+				ExpectedValue: "tag",
+			}},
+		},
+		{
+			Name:      "phpstan",
+			CheckType: "phpstan",
+			Severity:  "normal",
+			Status:    result.Fail,
+			Breaches: []breach.Breach{&breach.KeyValuesBreach{
+				Severity: "normal",
+				Key:      "file: /elsewhere/foo.php",
+				Values:   []string{"line 3: error 1", "line 10: error 2"},
+			}},
+		},
+		{
+			Name:     "base-images",
+			Severity: "normal",
+			Status:   result.Fail,
+			Breaches: []breach.Breach{&breach.KeyValueBreach{
+				Key:        "nginx",
+				Value:      "nginx:latest",
+				Suppressed: true,
+				Location: &breach.Location{
+					File: "docker-compose.yml", Line: 4, Column: 12},
+			}},
+		},
+	}}
+
+	var buf bytes.Buffer
+	s := &Stdout{}
+	s.Sarif(&rl, &buf)
+
+	var log SarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("2.1.0", log.Version)
+	assert.Len(log.Runs, 1)
+	run := log.Runs[0]
+
+	assert.Equal("shipshape", run.Tool.Driver.Name)
+	assert.Equal([]SarifRule{
+		{
+			Id:                   "passing",
+			Name:                 "passing",
+			ShortDescription:     SarifMessage{Text: "passing"},
+			DefaultConfiguration: SarifConfiguration{Level: "warning"},
+			Properties:           map[string]string{"severity": "normal"},
+		},
+		{
+			Id:                   "illegal-files",
+			Name:                 "illegal-files",
+			ShortDescription:     SarifMessage{Text: "illegal-files"},
+			DefaultConfiguration: SarifConfiguration{Level: "error"},
+			Properties:           map[string]string{"severity": "high"},
+		},
+		{
+			Id:                   "yaml/Views cache",
+			Name:                 "Views cache",
+			ShortDescription:     SarifMessage{Text: "Views cache"},
+			DefaultConfiguration: SarifConfiguration{Level: "note"},
+			Properties:           map[string]string{"severity": "low", "check-type": "yaml"},
+		},
+		{
+			Id:                   "phpstan/phpstan",
+			Name:                 "phpstan",
+			ShortDescription:     SarifMessage{Text: "phpstan"},
+			DefaultConfiguration: SarifConfiguration{Level: "warning"},
+			Properties:           map[string]string{"severity": "normal", "check-type": "phpstan"},
+		},
+		{
+			Id:                   "base-images",
+			Name:                 "base-images",
+			ShortDescription:     SarifMessage{Text: "base-images"},
+			DefaultConfiguration: SarifConfiguration{Level: "warning"},
+			Properties:           map[string]string{"severity": "normal"},
+		},
+	}, run.Tool.Driver.Rules)
+
+	srcRoot := func(uri string) SarifPhysicalLocation {
+		return SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{
+			Uri: uri, UriBaseId: SarifSrcRoot}}
+	}
+	assert.Equal([]SarifResult{
+		{
+			RuleId:    "illegal-files",
+			RuleIndex: 1,
+			Level:     "error",
+			Message:   SarifMessage{Text: "[disallowed value found] " + phpinfo},
+			Locations: []SarifLocation{{PhysicalLocation: srcRoot("web/phpinfo.php")}},
+		},
+		{
+			RuleId:    "illegal-files",
+			RuleIndex: 1,
+			Level:     "error",
+			Message:   SarifMessage{Text: "not a file"},
+			// No location found; located at the project's root.
+			Locations: []SarifLocation{{PhysicalLocation: srcRoot(".")}},
+		},
+		{
+			RuleId:    "yaml/Views cache",
+			RuleIndex: 2,
+			Level:     "note",
+			Message: SarifMessage{
+				Text: "[config:config/views.yml] 'cache.type' equals 'none', expected 'tag'"},
+			Locations: []SarifLocation{{PhysicalLocation: srcRoot("config/views.yml")}},
+		},
+		{
+			RuleId:    "phpstan/phpstan",
+			RuleIndex: 3,
+			Level:     "warning",
+			Message:   SarifMessage{Text: "file: /elsewhere/foo.php:\n        - line 3: error 1\n        - line 10: error 2"},
+			Locations: []SarifLocation{
+				{PhysicalLocation: SarifPhysicalLocation{
+					ArtifactLocation: SarifArtifactLocation{Uri: "file:///elsewhere/foo.php"},
+					Region:           &SarifRegion{StartLine: 3},
+				}},
+				{PhysicalLocation: SarifPhysicalLocation{
+					ArtifactLocation: SarifArtifactLocation{Uri: "file:///elsewhere/foo.php"},
+					Region:           &SarifRegion{StartLine: 10},
+				}},
+			},
+		},
+		{
+			RuleId:    "base-images",
+			RuleIndex: 4,
+			Level:     "warning",
+			Message:   SarifMessage{Text: "[:nginx] : nginx:latest"},
+			Locations: []SarifLocation{{PhysicalLocation: SarifPhysicalLocation{
+				ArtifactLocation: SarifArtifactLocation{
+					Uri: "docker-compose.yml", UriBaseId: SarifSrcRoot},
+				Region: &SarifRegion{StartLine: 4, StartColumn: 12},
+			}}},
+			Suppressions: []SarifSuppression{{
+				Kind: "external", Justification: "found in baseline"}},
+		},
+	}, run.Results)
+}
+
+func TestSarifAnalysers(t *testing.T) {
+	assert := assert.New(t)
+
+	// Analysers sharing a description, e.g, migrated from the same check.
+	rl := result.ResultList{Results: []result.Result{
+		{
+			Analyser: "modules-devel",
+			Name:     "Disallowed modules",
+			Severity: "high",
+			Status:   result.Fail,
+			Breaches: []breach.Breach{&breach.ValueBreach{Value: "devel"}},
+		},
+		{
+			Analyser: "modules-kint",
+			Name:     "Disallowed modules",
+			Severity: "high",
+			Status:   result.Fail,
+			Breaches: []breach.Breach{&breach.ValueBreach{Value: "kint"}},
+		},
+		{
+			Analyser: "modules-devel",
+			Name:     "Disallowed modules",
+			Severity: "high",
+			Status:   result.Fail,
+			Breaches: []breach.Breach{&breach.ValueBreach{Value: "stage_file_proxy"}},
+		},
+	}}
+
+	var buf bytes.Buffer
+	s := &Stdout{}
+	s.Sarif(&rl, &buf)
+
+	var log SarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := log.Runs[0]
+	assert.Len(run.Tool.Driver.Rules, 2)
+	assert.Equal("modules-devel", run.Tool.Driver.Rules[0].Id)
+	assert.Equal("modules-devel", run.Tool.Driver.Rules[0].Name)
+	assert.Equal(SarifMessage{Text: "Disallowed modules"}, run.Tool.Driver.Rules[0].ShortDescription)
+	assert.Equal("modules-kint", run.Tool.Driver.Rules[1].Id)
+
+	assert.Len(run.Results, 3)
+	assert.Equal("modules-devel", run.Results[0].RuleId)
+	assert.Equal(0, run.Results[0].RuleIndex)
+	assert.Equal("modules-kint", run.Results[1].RuleId)
+	assert.Equal(1, run.Results[1].RuleIndex)
+	assert.Equal("modules-devel", run.Results[2].RuleId)
+	assert.Equal(0, run.Results[2].RuleIndex)
+}
+
+func TestSarifWaived(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := result.ResultList{Results: []result.Result{{
+		Name:   "tfa",
+		Status: result.Pass,
+		Breaches: []breach.Breach{&breach.ValueBreach{
+			CheckName: "tfa",
+			Value:     "tfa disabled",
+			Waiver:    &breach.Waiver{Analyser: "tfa", Reason: "SSO enforced"},
+		}},
+	}}}
+
+	var buf bytes.Buffer
+	s := &Stdout{}
+	s.Sarif(&rl, &buf)
+
+	var log SarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal([]SarifSuppression{{Kind: "external", Justification: "SSO enforced"}},
+		log.Runs[0].Results[0].Suppressions)
+}
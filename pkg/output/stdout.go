@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"strings"
@@ -16,15 +15,22 @@ import (
 
 type Stdout struct {
 	// Plugin-specific fields.
-	// Format is the output format. One of "pretty", "table", "json", "junit".
+	// Format is the output format. One of "pretty", "table", "json", "junit",
+	// "sarif", "markdown", "html", "github", "codequality", "checkstyle".
 	Format string `yaml:"format"`
 }
 
-var OutputFormats = []string{"json", "pretty", "table", "junit"}
+var OutputFormats = []string{"json", "pretty", "table", "junit", "sarif", "markdown", "html",
+	"github", "codequality", "checkstyle"}
 var s = &Stdout{Format: "pretty"}
 
 func init() {
 	Outputters["stdout"] = s
+	// New instances start from the flags' values.
+	Factories["stdout"] = func() Outputter {
+		o := *s
+		return &o
+	}
 }
 
 func (p *Stdout) Output(rl *result.ResultList) ([]byte, error) {
@@ -42,6 +48,20 @@ func (p *Stdout) Output(rl *result.ResultList) ([]byte, error) {
 		fmt.Fprintln(&buf, string(data))
 	case "junit":
 		p.JUnit(rl, &buf)
+	case "sarif":
+		p.Sarif(rl, &buf)
+	case "markdown":
+		p.Markdown(rl, &buf)
+	case "html":
+		if err := p.HTML(rl, &buf); err != nil {
+			return nil, fmt.Errorf("unable to render html: %w", err)
+		}
+	case "github":
+		p.GithubActions(rl, &buf)
+	case "codequality":
+		p.CodeQuality(rl, &buf)
+	case "checkstyle":
+		p.Checkstyle(rl, &buf)
 	}
 	return buf.Bytes(), nil
 }
@@ -59,19 +79,20 @@ func (p *Stdout) Table(rl *result.ResultList, w io.Writer) {
 
 	fmt.Fprintf(tw, "NAME\tSTATUS\tPASSES\tFAILS\n")
 	for _, r := range rl.Results {
+		breaches := r.ActiveBreaches()
 		linePass = ""
 		lineFail = ""
 		if len(r.Passes) > 0 {
 			linePass = r.Passes[0]
 		}
-		if len(r.Breaches) > 0 {
-			lineFail = r.Breaches[0].String()
+		if len(breaches) > 0 {
+			lineFail = breaches[0].String()
 		}
 		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, r.Status, linePass, lineFail)
 
-		if len(r.Passes) > 1 || len(r.Breaches) > 1 {
+		if len(r.Passes) > 1 || len(breaches) > 1 {
 			numPasses := len(r.Passes)
-			numFailures := len(r.Breaches)
+			numFailures := len(breaches)
 
 			// How many additional lines?
 			numAddLines := numPasses
@@ -86,7 +107,7 @@ func (p *Stdout) Table(rl *result.ResultList, w io.Writer) {
 					linePass = r.Passes[i]
 				}
 				if numFailures > i {
-					lineFail = r.Breaches[i].String()
+					lineFail = breaches[i].String()
 				}
 				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", "", "", linePass, lineFail)
 			}
@@ -149,6 +170,8 @@ func (p *Stdout) Pretty(rl *result.ResultList, w io.Writer) {
 		}
 	} else if rl.Status() == result.Pass {
 		fmt.Fprint(buf, "Ship is in top shape; no breach detected!\n")
+		printWaived(buf, rl)
+		printSuppressed(buf, rl)
 		buf.Flush()
 		return
 	}
@@ -158,15 +181,18 @@ func (p *Stdout) Pretty(rl *result.ResultList, w io.Writer) {
 	}
 
 	for _, r := range rl.Results {
-		if len(r.Breaches) == 0 || r.RemediationStatus == remediation.RemediationStatusSuccess {
+		if len(r.ActiveBreaches()) == 0 || r.RemediationStatus == remediation.RemediationStatusSuccess {
 			continue
 		}
 		fmt.Fprintf(buf, "  ### %s\n", r.Name)
-		for _, b := range r.Breaches {
+		for _, b := range r.ActiveBreaches() {
 			if b.GetRemediationResult().Status == remediation.RemediationStatusSuccess {
 				continue
 			}
 			fmt.Fprintf(buf, "     -- %s\n", b)
+			if loc := b.GetLocation(); loc != nil {
+				fmt.Fprintf(buf, "        at %s\n", loc)
+			}
 			if r.RemediationStatus == remediation.RemediationStatusFailed {
 				fmt.Fprintf(buf, "        !!! Remediation failed:\n")
 				for _, msg := range b.GetRemediationResult().Messages {
@@ -176,9 +202,50 @@ func (p *Stdout) Pretty(rl *result.ResultList, w io.Writer) {
 		}
 		fmt.Fprintln(buf)
 	}
+	printWaived(buf, rl)
+	printSuppressed(buf, rl)
 	buf.Flush()
 }
 
+// printWaived lists the waived breaches along with the reason for their
+// waiver.
+func printWaived(w io.Writer, rl *result.ResultList) {
+	if rl.TotalWaived == 0 {
+		return
+	}
+
+	fmt.Fprint(w, "\n# Waived breaches\n\n")
+	for _, r := range rl.Results {
+		printed := false
+		for _, b := range r.Breaches {
+			if b.GetWaiver() == nil || b.IsSuppressed() {
+				continue
+			}
+			if !printed {
+				fmt.Fprintf(w, "  ### %s\n", r.Name)
+				printed = true
+			}
+			fmt.Fprintf(w, "     -- %s\n", b)
+			fmt.Fprintf(w, "        waived: %s\n", b.GetWaiver())
+		}
+		if printed {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// printSuppressed mentions the number of breaches which were not reported as
+// they are suppressed.
+func printSuppressed(w io.Writer, rl *result.ResultList) {
+	switch rl.TotalSuppressed {
+	case 0:
+	case 1:
+		fmt.Fprint(w, "1 suppressed breach was not reported.\n")
+	default:
+		fmt.Fprintf(w, "%d suppressed breaches were not reported.\n", rl.TotalSuppressed)
+	}
+}
+
 // TabbedMultiline prepends a given tab string
 // to each line in a multiline string.
 func TabbedMultiline(tab, s string) string {
@@ -189,49 +256,3 @@ func TabbedMultiline(tab, s string) string {
 	}
 	return strings.Join(lines, "\n")
 }
-
-// JUnit outputs the checks results in the JUnit XML format.
-func (p *Stdout) JUnit(rl *result.ResultList, w io.Writer) {
-	buf := bufio.NewWriter(w)
-	tss := JUnitTestSuites{
-		Tests:      rl.TotalChecks,
-		Errors:     rl.TotalBreaches,
-		TestSuites: []JUnitTestSuite{},
-	}
-
-	// Create a JUnitTestSuite for each CheckType.
-	for pplugin, policies := range rl.Policies {
-		ts := JUnitTestSuite{
-			Name:      pplugin,
-			Tests:     rl.CheckCountByType[pplugin],
-			Errors:    rl.BreachCountByType[pplugin],
-			TestCases: []JUnitTestCase{},
-		}
-
-		// Create a JUnitTestCase for each Check.
-		for _, plc := range policies {
-			tc := JUnitTestCase{
-				Name:      plc,
-				ClassName: plc,
-				Errors:    []JUnitError{},
-			}
-
-			for _, b := range rl.GetBreachesByCheckName(plc) {
-				tc.Errors = append(tc.Errors, JUnitError{Message: b.String()})
-			}
-			ts.TestCases = append(ts.TestCases, tc)
-		}
-		tss.TestSuites = append(tss.TestSuites, ts)
-	}
-
-	xmlBytes, err := xml.MarshalIndent(tss, "", "    ")
-	if err != nil {
-		fmt.Fprintf(buf, "error occurred while converting to XML: %s\n", err.Error())
-		buf.Flush()
-		return
-	}
-	fmt.Fprint(buf, xml.Header)
-	fmt.Fprint(buf, string(xmlBytes))
-	fmt.Fprintln(buf)
-	buf.Flush()
-}
@@ -132,6 +132,77 @@ func TestPrettyDisplay(t *testing.T) {
 			},
 			expected: "# Breaches were detected\n\n  ### b\n     -- Fail b\n\n",
 		},
+		{
+			name: "breachesSuppressed",
+			rl: result.ResultList{
+				TotalSuppressed: 2,
+				Results: []result.Result{
+					{
+						Name:     "a",
+						Status:   result.Pass,
+						Breaches: []breach.Breach{&breach.ValueBreach{Value: "Fail a", Suppressed: true}},
+					},
+					{
+						Name:   "b",
+						Status: result.Fail,
+						Breaches: []breach.Breach{
+							&breach.ValueBreach{Value: "Fail b"},
+							&breach.ValueBreach{Value: "Fail c", Suppressed: true},
+						},
+					},
+				},
+			},
+			expected: "# Breaches were detected\n\n  ### b\n     -- Fail b\n\n" +
+				"2 suppressed breaches were not reported.\n",
+		},
+		{
+			name: "allBreachesSuppressed",
+			rl: result.ResultList{
+				TotalSuppressed: 1,
+				Results: []result.Result{{
+					Name:     "a",
+					Status:   result.Pass,
+					Breaches: []breach.Breach{&breach.ValueBreach{Value: "Fail a", Suppressed: true}},
+				}},
+			},
+			expected: "Ship is in top shape; no breach detected!\n" +
+				"1 suppressed breach was not reported.\n",
+		},
+		{
+			name: "breachesWaived",
+			rl: result.ResultList{
+				TotalWaived: 1,
+				Results: []result.Result{{
+					Name:   "a",
+					Status: result.Pass,
+					Breaches: []breach.Breach{&breach.ValueBreach{
+						Value: "Fail a",
+						Waiver: &breach.Waiver{
+							Reason: "SSO enforced", Owner: "security", Expires: "2026-12-31"},
+					}},
+				}},
+			},
+			expected: "Ship is in top shape; no breach detected!\n\n" +
+				"# Waived breaches\n\n  ### a\n     -- Fail a\n" +
+				"        waived: SSO enforced (owner: security, expires: 2026-12-31)\n\n",
+		},
+		{
+			name: "breachLocation",
+			rl: result.ResultList{
+				Results: []result.Result{{
+					Name:   "b",
+					Status: result.Fail,
+					Breaches: []breach.Breach{
+						&breach.ValueBreach{
+							Value:    "Fail b",
+							Location: &breach.Location{File: "docker-compose.yml", Line: 12, Column: 5},
+						},
+					},
+				}},
+			},
+			expected: "# Breaches were detected\n\n  ### b\n     -- Fail b\n" +
+				"        at docker-compose.yml:12:5\n\n",
+		},
 		{
 			name: "topShapeRemediating",
 			rl: result.ResultList{
@@ -225,66 +296,3 @@ func TestPrettyDisplay(t *testing.T) {
 		})
 	}
 }
-
-func TestJUnit(t *testing.T) {
-	tt := []struct {
-		name     string
-		rl       result.ResultList
-		expected string
-	}{
-		{
-			name: "noResult",
-			rl:   result.NewResultList(false),
-			expected: `<?xml version="1.0" encoding="UTF-8"?>
-<testsuites tests="0" errors="0"></testsuites>
-`,
-		},
-		{
-			name: "allPass",
-			rl: result.ResultList{
-				Policies: map[string][]string{"test-check": {"a"}},
-				Results:  []result.Result{{Name: "a", Status: result.Pass}}},
-			expected: `<?xml version="1.0" encoding="UTF-8"?>
-<testsuites tests="0" errors="0">
-    <testsuite name="test-check" tests="0" errors="0">
-        <testcase name="a" classname="a"></testcase>
-    </testsuite>
-</testsuites>
-`,
-		},
-		{
-			name: "mixedPassFail",
-			rl: result.ResultList{
-				Policies: map[string][]string{"test-check": {"a", "b"}},
-				Results: []result.Result{
-					{Name: "a", Status: result.Pass},
-					{
-						Name:     "b",
-						Status:   result.Fail,
-						Breaches: []breach.Breach{&breach.ValueBreach{Value: "Fail b"}},
-					},
-				},
-			},
-			expected: `<?xml version="1.0" encoding="UTF-8"?>
-<testsuites tests="0" errors="0">
-    <testsuite name="test-check" tests="0" errors="0">
-        <testcase name="a" classname="a"></testcase>
-        <testcase name="b" classname="b">
-            <error message="Fail b"></error>
-        </testcase>
-    </testsuite>
-</testsuites>
-`,
-		},
-	}
-
-	for _, tc := range tt {
-		t.Run(tc.name, func(t *testing.T) {
-			assert := assert.New(t)
-			var buf bytes.Buffer
-			s := &Stdout{}
-			s.JUnit(&tc.rl, &buf)
-			assert.Equal(tc.expected, buf.String())
-		})
-	}
-}
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// Template renders the results using a user-defined Go template, e.g, for a
+// ticket body, an email or a custom csv.
+type Template struct {
+	// Plugin-specific fields.
+	// Path is the template file.
+	Path string `yaml:"path"`
+	// Html renders the template using html/template, which escapes the
+	// values for html, instead of text/template.
+	Html bool `yaml:"html"`
+}
+
+// BreachGroup is a group of breaches sharing a severity or check type.
+type BreachGroup struct {
+	Key      string
+	Breaches []breach.Breach
+}
+
+type templateExecutor interface {
+	Execute(io.Writer, any) error
+}
+
+var t = &Template{}
+
+func init() {
+	Outputters["template"] = t
+	Factories["template"] = func() Outputter { return &Template{} }
+}
+
+// TemplateFuncs returns the functions available to the templates: the
+// breach template functions, along with helpers to list and group breaches
+// and to access their fields.
+func TemplateFuncs() template.FuncMap {
+	funcs := template.FuncMap{}
+	for k, v := range breach.TemplateFuncs {
+		funcs[k] = v
+	}
+	funcs["breaches"] = ActiveBreaches
+	funcs["bySeverity"] = GroupBySeverity
+	funcs["byType"] = GroupByType
+	funcs["breachKeyLabel"] = breach.BreachGetKeyLabel
+	funcs["breachKey"] = breach.BreachGetKey
+	funcs["breachValueLabel"] = breach.BreachGetValueLabel
+	funcs["breachValue"] = breach.BreachGetValue
+	funcs["breachValues"] = breach.BreachGetValues
+	funcs["breachExpectedValue"] = breach.BreachGetExpectedValue
+	funcs["fingerprint"] = breach.Fingerprint
+	funcs["join"] = strings.Join
+	funcs["json"] = templateJson
+	return funcs
+}
+
+// Output renders the template, if configured.
+func (p *Template) Output(rl *result.ResultList) ([]byte, error) {
+	if p.Path == "" {
+		log.Debug("skipping template output, no path configured")
+		return nil, nil
+	}
+
+	var tmpl templateExecutor
+	var err error
+	name := filepath.Base(p.Path)
+	if p.Html {
+		tmpl, err = htmltemplate.New(name).
+			Funcs(htmltemplate.FuncMap(TemplateFuncs())).ParseFiles(p.Path)
+	} else {
+		tmpl, err = template.New(name).Funcs(TemplateFuncs()).ParseFiles(p.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s': %w", p.Path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, rl); err != nil {
+		return nil, fmt.Errorf("failed to render template '%s': %w", p.Path, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// templateJson converts the value to json, e.g, to embed a string in a json
+// template.
+func templateJson(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// ActiveBreaches returns the active breaches of all the results.
+func ActiveBreaches(rl *result.ResultList) []breach.Breach {
+	breaches := []breach.Breach{}
+	for _, r := range rl.Results {
+		breaches = append(breaches, r.ActiveBreaches()...)
+	}
+	return breaches
+}
+
+// GroupBySeverity groups the breaches by severity, most severe first.
+func GroupBySeverity(breaches []breach.Breach) []BreachGroup {
+	groups := groupBreaches(breaches, breach.Breach.GetSeverity)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return config.Severity(groups[i].Key).Level() > config.Severity(groups[j].Key).Level()
+	})
+	return groups
+}
+
+// GroupByType groups the breaches by check type.
+func GroupByType(breaches []breach.Breach) []BreachGroup {
+	return groupBreaches(breaches, breach.Breach.GetCheckType)
+}
+
+// groupBreaches groups the breaches by the given key, sorted by key; the
+// breaches keep their order within a group.
+func groupBreaches(breaches []breach.Breach, key func(breach.Breach) string) []BreachGroup {
+	groups := []BreachGroup{}
+	index := map[string]int{}
+	for _, b := range breaches {
+		k := key(b)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, BreachGroup{Key: k})
+		}
+		groups[i].Breaches = append(groups[i].Breaches, b)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Key < groups[j].Key })
+	return groups
+}
@@ -0,0 +1,109 @@
+package output_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func templateResultList() result.ResultList {
+	return result.ResultList{
+		TotalBreaches: 3,
+		Results: []result.Result{
+			{
+				Name:   "a",
+				Status: result.Fail,
+				Breaches: []breach.Breach{
+					&breach.KeyValueBreach{CheckType: "yaml", Severity: "normal", Key: "editor", Value: "<b>"},
+					&breach.ValueBreach{CheckType: "file", Severity: "high", Value: "Fail a"},
+					&breach.ValueBreach{CheckType: "file", Severity: "high", Value: "Fail waived",
+						Waiver: &breach.Waiver{Reason: "ok"}},
+				},
+			},
+			{
+				Name:   "b",
+				Status: result.Fail,
+				Breaches: []breach.Breach{
+					&breach.KeyValuesBreach{CheckType: "yaml", Severity: "critical", Key: "admin",
+						Values: []string{"x", "y"}},
+				},
+			},
+		},
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	tt := []struct {
+		name        string
+		template    string
+		html        bool
+		expected    string
+		expectedErr string
+	}{
+		{
+			name: "resultList",
+			template: "{{ .TotalBreaches }} breaches\n" +
+				"{{ range .Results }}{{ .Name }}: {{ .Status }}\n{{ end }}",
+			expected: "3 breaches\na: Fail\nb: Fail\n",
+		},
+		{
+			name: "bySeverity",
+			template: "{{ range bySeverity (breaches .) }}{{ .Key }}:" +
+				"{{ range .Breaches }} {{ breachKey . }}{{ breachValue . }}" +
+				"{{ join (breachValues .) \",\" }}{{ end }}\n{{ end }}",
+			expected: "critical: adminx,y\nhigh: Fail a\nnormal: editor<b>\n",
+		},
+		{
+			name:     "byType",
+			template: "{{ range byType (breaches .) }}{{ .Key }}={{ len .Breaches }} {{ end }}",
+			expected: "file=1 yaml=2 ",
+		},
+		{
+			name:     "html",
+			template: "{{ range breaches . }}<p>{{ breachValue . }}</p>{{ end }}",
+			html:     true,
+			expected: "<p>&lt;b&gt;</p><p>Fail a</p><p></p>",
+		},
+		{
+			name:        "parseError",
+			template:    "{{ .Foo",
+			expectedErr: "failed to parse template",
+		},
+		{
+			name:        "renderError",
+			template:    "{{ .Foo }}",
+			expectedErr: "failed to render template",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path := filepath.Join(t.TempDir(), "report.tmpl")
+			assert.NoError(os.WriteFile(path, []byte(tc.template), 0644))
+
+			rl := templateResultList()
+			out, err := (&Template{Path: path, Html: tc.html}).Output(&rl)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(err, tc.expectedErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expected, string(out))
+		})
+	}
+
+	t.Run("noPath", func(t *testing.T) {
+		rl := templateResultList()
+		out, err := (&Template{}).Output(&rl)
+		assert.NoError(t, err)
+		assert.Nil(t, out)
+	})
+}
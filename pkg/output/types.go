@@ -4,30 +4,171 @@ import (
 	"encoding/xml"
 )
 
-type JUnitError struct {
-	XMLName xml.Name `xml:"error"`
+// JUnitFailure is used for each active breach of a check.
+type JUnitFailure struct {
+	XMLName xml.Name `xml:"failure"`
 	Message string   `xml:"message,attr"`
+	Type    string   `xml:"type,attr"`
+	File    string   `xml:"file,attr,omitempty"`
+	Line    int      `xml:"line,attr,omitempty"`
+	Text    string   `xml:",chardata"`
+}
+
+// JUnitSkipped is used for breaches which are waived or suppressed.
+type JUnitSkipped struct {
+	XMLName xml.Name `xml:"skipped"`
+	Message string   `xml:"message,attr"`
+}
+
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type JUnitTestCase struct {
-	XMLName   xml.Name `xml:"testcase"`
-	Name      string   `xml:"name,attr"`
-	ClassName string   `xml:"classname,attr"`
-	Errors    []JUnitError
+	XMLName    xml.Name        `xml:"testcase"`
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	Failures   []JUnitFailure
+	Skipped    []JUnitSkipped
 }
 
 type JUnitTestSuite struct {
 	XMLName   xml.Name `xml:"testsuite"`
 	Name      string   `xml:"name,attr"`
 	Tests     int      `xml:"tests,attr"`
+	Failures  int      `xml:"failures,attr"`
 	Errors    int      `xml:"errors,attr"`
+	Skipped   int      `xml:"skipped,attr"`
+	Time      string   `xml:"time,attr"`
 	TestCases []JUnitTestCase
 }
 
 // JUnit format taken from https://llg.cubic.org/docs/junit/.
 type JUnitTestSuites struct {
 	XMLName    xml.Name `xml:"testsuites"`
-	Tests      uint32   `xml:"tests,attr"`
-	Errors     uint32   `xml:"errors,attr"`
+	Tests      int      `xml:"tests,attr"`
+	Failures   int      `xml:"failures,attr"`
+	Errors     int      `xml:"errors,attr"`
+	Skipped    int      `xml:"skipped,attr"`
+	Time       string   `xml:"time,attr"`
 	TestSuites []JUnitTestSuite
 }
+
+// SARIF format taken from https://docs.oasis-open.org/sarif/sarif/v2.1.0/.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool               SarifTool                        `json:"tool"`
+	OriginalUriBaseIds map[string]SarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []SarifResult                    `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	Id                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     SarifMessage       `json:"shortDescription"`
+	DefaultConfiguration SarifConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]string  `json:"properties,omitempty"`
+}
+
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+	// Suppressions is set for breaches which are suppressed, e.g, by a
+	// baseline.
+	Suppressions []SarifSuppression `json:"suppressions,omitempty"`
+}
+
+type SarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	Uri       string `json:"uri"`
+	UriBaseId string `json:"uriBaseId,omitempty"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// Code Quality format taken from
+// https://docs.gitlab.com/ee/ci/testing/code_quality.html#code-quality-report-format.
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+type CodeQualityLocation struct {
+	Path  string            `json:"path"`
+	Lines *CodeQualityLines `json:"lines,omitempty"`
+}
+
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// Checkstyle format taken from https://checkstyle.sourceforge.io/.
+type Checkstyle struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
@@ -0,0 +1,196 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+const (
+	// WebhookPerRun sends a single request with the result list.
+	WebhookPerRun = "run"
+	// WebhookPerResult sends a request for each result.
+	WebhookPerResult = "result"
+	// WebhookPerBreach sends a request for each active breach.
+	WebhookPerBreach = "breach"
+)
+
+// Webhook sends the results to a url, e.g, a chat, a ticketing system or a
+// dashboard.
+type Webhook struct {
+	// Plugin-specific fields.
+	// Url is the url to send the requests to; environment variables are
+	// expanded, e.g, "https://hooks.slack.com/services/${SLACK_TOKEN}".
+	Url string `yaml:"url"`
+	// Method is the request's method. Defaults to POST.
+	Method string `yaml:"method"`
+	// Headers are the request's headers; environment variables are
+	// expanded in their values, e.g, "Bearer ${TOKEN}".
+	Headers map[string]string `yaml:"headers"`
+	// Body is a Go template for the request's body, with the same functions
+	// as the template outputter. Defaults to the data as json.
+	Body string `yaml:"body"`
+	// Per determines what a request is sent for: "run" (default), "result"
+	// or "breach".
+	Per string `yaml:"per"`
+	// Retries is the number of times a failed request is retried. Requests
+	// failing with a client error other than 429 are not retried.
+	Retries int `yaml:"retries"`
+	// Backoff is the delay before the first retry, doubled for each of the
+	// following ones, e.g, "1s".
+	Backoff string `yaml:"backoff"`
+	// Timeout is the timeout of each request, e.g, "10s".
+	Timeout string `yaml:"timeout"`
+}
+
+// WebhookBreach is the data sent for each breach.
+type WebhookBreach struct {
+	// Name is the name of the breach's result.
+	Name     string        `json:"name"`
+	Severity string        `json:"severity"`
+	Breach   breach.Breach `json:"breach"`
+}
+
+var wh = &Webhook{}
+
+func init() {
+	Outputters["webhook"] = wh
+	Factories["webhook"] = func() Outputter { return &Webhook{} }
+}
+
+// Output sends the requests, if configured, returning no output.
+func (p *Webhook) Output(rl *result.ResultList) ([]byte, error) {
+	if p.Url == "" {
+		log.Debug("skipping webhook output, no url configured")
+		return nil, nil
+	}
+
+	backoff, err := ParseDuration(p.Backoff, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook backoff: %w", err)
+	}
+	timeout, err := ParseDuration(p.Timeout, 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook timeout: %w", err)
+	}
+
+	var tmpl *template.Template
+	if p.Body != "" {
+		tmpl, err = template.New("webhook").Funcs(TemplateFuncs()).Parse(p.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse webhook body template: %w", err)
+		}
+	}
+
+	payloads := []any{}
+	switch p.Per {
+	case "", WebhookPerRun:
+		payloads = append(payloads, rl)
+	case WebhookPerResult:
+		for _, r := range rl.Results {
+			payloads = append(payloads, r)
+		}
+	case WebhookPerBreach:
+		for _, r := range rl.Results {
+			for _, b := range unresolvedBreaches(r) {
+				payloads = append(payloads, WebhookBreach{
+					Name: r.Name, Severity: breachSeverity(r, b), Breach: b})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported webhook per '%s', expected one of: %s",
+			p.Per, strings.Join([]string{WebhookPerRun, WebhookPerResult, WebhookPerBreach}, ", "))
+	}
+
+	client := &http.Client{Timeout: timeout}
+	target := os.ExpandEnv(p.Url)
+	errs := []error{}
+	for _, data := range payloads {
+		body, err := webhookBody(tmpl, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := p.send(client, target, body, backoff); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	log.WithFields(log.Fields{"requests": len(payloads), "failed": len(errs)}).
+		Info("sent results to webhook")
+	return nil, errors.Join(errs...)
+}
+
+func webhookBody(tmpl *template.Template, data any) ([]byte, error) {
+	if tmpl == nil {
+		body, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert webhook body to json: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render webhook body template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// send makes the request, retrying on network errors, server errors and
+// rate limiting.
+func (p *Webhook) send(client *http.Client, target string, body []byte, backoff time.Duration) error {
+	method := p.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	return Retry("webhook request", p.Retries, backoff, func() (bool, error) {
+		return p.request(client, method, target, body)
+	})
+}
+
+// request makes a single request, determining whether it can be retried if it
+// failed. Errors leave out the url, which may contain a secret.
+func (p *Webhook) request(client *http.Client, method string, target string, body []byte) (bool, error) {
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return false, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return true, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook request failed with status %d: %s",
+		resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
@@ -0,0 +1,173 @@
+package output_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+type webhookRequest struct {
+	Method string
+	Header http.Header
+	Body   string
+}
+
+// webhookServer records the requests, responding with the given statuses in
+// turn, then 200.
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, *[]webhookRequest) {
+	requests := []webhookRequest{}
+	lock := sync.Mutex{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, webhookRequest{Method: r.Method, Header: r.Header, Body: string(body)})
+		if len(requests) <= len(statuses) {
+			w.WriteHeader(statuses[len(requests)-1])
+			w.Write([]byte("nope"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func webhookResultList() result.ResultList {
+	return result.ResultList{
+		TotalBreaches: 2,
+		Results: []result.Result{
+			{
+				Name:     "a",
+				Severity: "high",
+				Status:   result.Fail,
+				Breaches: []breach.Breach{
+					&breach.ValueBreach{BreachType: breach.BreachTypeValue, Value: "Fail a"},
+					&breach.ValueBreach{BreachType: breach.BreachTypeValue, Value: "Fail b", Severity: "low"},
+					&breach.ValueBreach{BreachType: breach.BreachTypeValue, Value: "waived", Waiver: &breach.Waiver{Reason: "ok"}},
+				},
+			},
+			{Name: "b", Severity: "normal", Status: result.Pass},
+		},
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	t.Run("noUrl", func(t *testing.T) {
+		rl := webhookResultList()
+		out, err := (&Webhook{}).Output(&rl)
+		assert.NoError(t, err)
+		assert.Nil(t, out)
+	})
+
+	t.Run("perRun", func(t *testing.T) {
+		assert := assert.New(t)
+		t.Setenv("WEBHOOK_TOKEN", "s3cr3t")
+		srv, requests := webhookServer(t)
+
+		rl := webhookResultList()
+		out, err := (&Webhook{
+			Url:     srv.URL,
+			Method:  http.MethodPut,
+			Headers: map[string]string{"Authorization": "Bearer ${WEBHOOK_TOKEN}"},
+		}).Output(&rl)
+		assert.NoError(err)
+		assert.Nil(out)
+
+		assert.Len(*requests, 1)
+		req := (*requests)[0]
+		assert.Equal(http.MethodPut, req.Method)
+		assert.Equal("Bearer s3cr3t", req.Header.Get("Authorization"))
+		assert.Equal("application/json", req.Header.Get("Content-Type"))
+		sent := result.ResultList{}
+		assert.NoError(json.Unmarshal([]byte(req.Body), &sent))
+		assert.Equal(uint32(2), sent.TotalBreaches)
+		assert.Len(sent.Results, 2)
+	})
+
+	t.Run("perResult", func(t *testing.T) {
+		assert := assert.New(t)
+		srv, requests := webhookServer(t)
+
+		rl := webhookResultList()
+		_, err := (&Webhook{
+			Url:  srv.URL,
+			Per:  WebhookPerResult,
+			Body: `{"text": {{ json (printf "%s: %s" .Name .Status) }}}`,
+		}).Output(&rl)
+		assert.NoError(err)
+		assert.Len(*requests, 2)
+		assert.Equal(http.MethodPost, (*requests)[0].Method)
+		assert.Equal(`{"text": "a: Fail"}`, (*requests)[0].Body)
+		assert.Equal(`{"text": "b: Pass"}`, (*requests)[1].Body)
+	})
+
+	t.Run("perBreach", func(t *testing.T) {
+		assert := assert.New(t)
+		srv, requests := webhookServer(t)
+
+		rl := webhookResultList()
+		_, err := (&Webhook{
+			Url:  srv.URL,
+			Per:  WebhookPerBreach,
+			Body: `{{ .Severity }} {{ .Name }} {{ breachValue .Breach }}`,
+		}).Output(&rl)
+		assert.NoError(err)
+		assert.Len(*requests, 2)
+		assert.Equal("high a Fail a", (*requests)[0].Body)
+		assert.Equal("low a Fail b", (*requests)[1].Body)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		assert := assert.New(t)
+		srv, requests := webhookServer(t, http.StatusInternalServerError,
+			http.StatusTooManyRequests)
+
+		rl := webhookResultList()
+		_, err := (&Webhook{Url: srv.URL, Retries: 2, Backoff: "1ms"}).Output(&rl)
+		assert.NoError(err)
+		assert.Len(*requests, 3)
+	})
+
+	t.Run("retriesExhausted", func(t *testing.T) {
+		assert := assert.New(t)
+		srv, requests := webhookServer(t, http.StatusBadGateway,
+			http.StatusBadGateway, http.StatusBadGateway)
+
+		rl := webhookResultList()
+		_, err := (&Webhook{Url: srv.URL, Retries: 1, Backoff: "1ms"}).Output(&rl)
+		assert.EqualError(err, "webhook request failed with status 502: nope")
+		assert.Len(*requests, 2)
+	})
+
+	t.Run("clientErrorNotRetried", func(t *testing.T) {
+		assert := assert.New(t)
+		srv, requests := webhookServer(t, http.StatusBadRequest)
+
+		rl := webhookResultList()
+		_, err := (&Webhook{Url: srv.URL, Retries: 3, Backoff: "1ms"}).Output(&rl)
+		assert.EqualError(err, "webhook request failed with status 400: nope")
+		assert.Len(*requests, 1)
+	})
+
+	t.Run("invalidConfig", func(t *testing.T) {
+		assert := assert.New(t)
+		rl := webhookResultList()
+
+		_, err := (&Webhook{Url: "http://localhost", Per: "check"}).Output(&rl)
+		assert.EqualError(err, "unsupported webhook per 'check', expected one of: run, result, breach")
+
+		_, err = (&Webhook{Url: "http://localhost", Backoff: "1"}).Output(&rl)
+		assert.ErrorContains(err, "invalid webhook backoff")
+
+		_, err = (&Webhook{Url: "http://localhost", Body: "{{ .Foo"}).Output(&rl)
+		assert.ErrorContains(err, "failed to parse webhook body template")
+	})
+}
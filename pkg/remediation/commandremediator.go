@@ -1,6 +1,10 @@
 package remediation
 
-import "github.com/salsadigitalauorg/shipshape/pkg/command"
+import (
+	"context"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/command"
+)
 
 type CommandRemediator struct {
 	// Common fields.
@@ -17,8 +21,8 @@ func init() {
 	Registry["command"] = func() Remediator { return &CommandRemediator{} }
 }
 
-func (p *CommandRemediator) Remediate() RemediationResult {
-	_, err := command.ShellCommander(p.Command, p.Arguments...).Output()
+func (p *CommandRemediator) Remediate(ctx context.Context) RemediationResult {
+	_, err := command.ShellCommanderContext(ctx, p.Command, p.Arguments...).Output()
 	if err != nil {
 		return RemediationResult{
 			Status:   RemediationStatusFailed,
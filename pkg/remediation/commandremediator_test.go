@@ -0,0 +1,44 @@
+package remediation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/command"
+	"github.com/salsadigitalauorg/shipshape/pkg/internal"
+	. "github.com/salsadigitalauorg/shipshape/pkg/remediation"
+)
+
+func TestCommandRemediatorRemediate(t *testing.T) {
+	curShellCommanderContext := command.ShellCommanderContext
+	defer func() { command.ShellCommanderContext = curShellCommanderContext }()
+
+	t.Run("success", func(t *testing.T) {
+		assert := assert.New(t)
+		var generatedCommand string
+		command.ShellCommanderContext = internal.ShellCommanderContextMaker(
+			nil, nil, &generatedCommand)
+
+		r := &CommandRemediator{Command: "drush", Arguments: []string{"pm:uninstall", "foo"}}
+		assert.Equal(RemediationResult{
+			Status:   RemediationStatusSuccess,
+			Messages: []string{"remediation successful"},
+		}, r.Remediate(context.Background()))
+		assert.Equal("drush pm:uninstall foo", generatedCommand)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		assert := assert.New(t)
+		command.ShellCommanderContext = internal.ShellCommanderContextMaker(
+			nil, errors.New("command failed"), nil)
+
+		r := &CommandRemediator{Command: "drush"}
+		assert.Equal(RemediationResult{
+			Status:   RemediationStatusFailed,
+			Messages: []string{"command failed"},
+		}, r.Remediate(context.Background()))
+	})
+}
@@ -1,6 +1,7 @@
 package remediation
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -10,7 +11,7 @@ import (
 
 type Remediator interface {
 	PluginName() string
-	Remediate() RemediationResult
+	Remediate(ctx context.Context) RemediationResult
 	GetRemediationMessage() string
 }
 
@@ -1,6 +1,7 @@
 package remediation_test
 
 import (
+	"context"
 	"io"
 	"math"
 	"testing"
@@ -29,7 +30,7 @@ func TestRemediator(t *testing.T) {
 	assert.Equal(RemediationResult{
 		Status:   RemediationStatusFailed,
 		Messages: []string{"foo"},
-	}, testrem.Remediate())
+	}, testrem.Remediate(context.Background()))
 }
 
 func TestRemediatorFromInterface(t *testing.T) {
@@ -1,6 +1,10 @@
 package testdata
 
-import "github.com/salsadigitalauorg/shipshape/pkg/remediation"
+import (
+	"context"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+)
 
 type TestRemediator struct {
 	// Common fields.
@@ -18,6 +22,6 @@ func (p *TestRemediator) GetRemediationMessage() string {
 	return p.Message
 }
 
-func (p *TestRemediator) Remediate() remediation.RemediationResult {
+func (p *TestRemediator) Remediate(ctx context.Context) remediation.RemediationResult {
 	return p.ExpectedRemediationResult
 }
@@ -0,0 +1,131 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+)
+
+// BaselineVersion is the version of the baseline file format.
+const BaselineVersion = 1
+
+// Baseline is a record of known breaches, which are suppressed rather than
+// failing when found again in later runs.
+type Baseline struct {
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `json:"created-at"`
+	Breaches  []BaselineBreach `json:"breaches"`
+}
+
+// BaselineBreach is a known breach; only its fingerprint is used for matching,
+// the other fields making the file easier to review.
+type BaselineBreach struct {
+	Fingerprint string `json:"fingerprint"`
+	CheckName   string `json:"check-name"`
+	Breach      string `json:"breach"`
+}
+
+// ErrBaselineVersion is returned when a baseline's version is not supported.
+type ErrBaselineVersion struct {
+	Version int
+}
+
+func (e *ErrBaselineVersion) Error() string {
+	return fmt.Sprintf("unsupported baseline version %d, expected %d",
+		e.Version, BaselineVersion)
+}
+
+// NewBaseline creates a baseline from all the breaches in the result list,
+// suppressed or not, except for the waived ones which are already accepted.
+func NewBaseline(rl *ResultList) *Baseline {
+	b := &Baseline{
+		Version:   BaselineVersion,
+		CreatedAt: time.Now().UTC(),
+		Breaches:  []BaselineBreach{},
+	}
+
+	seen := map[string]bool{}
+	for _, r := range rl.Results {
+		for _, br := range r.Breaches {
+			if br.GetWaiver() != nil {
+				continue
+			}
+			fp := breach.Fingerprint(br)
+			if seen[fp] {
+				continue
+			}
+			seen[fp] = true
+			b.Breaches = append(b.Breaches, BaselineBreach{
+				Fingerprint: fp,
+				CheckName:   br.GetCheckName(),
+				Breach:      br.String(),
+			})
+		}
+	}
+
+	sort.Slice(b.Breaches, func(i, j int) bool {
+		if b.Breaches[i].CheckName != b.Breaches[j].CheckName {
+			return b.Breaches[i].CheckName < b.Breaches[j].CheckName
+		}
+		return b.Breaches[i].Fingerprint < b.Breaches[j].Fingerprint
+	})
+	return b
+}
+
+// ReadBaseline reads a baseline from the given file.
+func ReadBaseline(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Baseline{}
+	if err := json.Unmarshal(content, b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline '%s': %w", path, err)
+	}
+	if b.Version != BaselineVersion {
+		return nil, &ErrBaselineVersion{Version: b.Version}
+	}
+	return b, nil
+}
+
+// Save writes the baseline to the given file.
+func (b *Baseline) Save(path string) error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"path":     path,
+		"breaches": len(b.Breaches),
+	}).Info("saving baseline")
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// ApplyBaseline suppresses the breaches found in the baseline, then
+// recalculates the results' status and the totals.
+func (rl *ResultList) ApplyBaseline(b *Baseline) {
+	known := map[string]bool{}
+	for _, bb := range b.Breaches {
+		known[bb.Fingerprint] = true
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, r := range rl.Results {
+		for _, br := range r.Breaches {
+			if known[breach.Fingerprint(br)] {
+				br.SetSuppressed(true)
+			}
+		}
+	}
+	rl.recount()
+}
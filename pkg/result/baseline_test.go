@@ -0,0 +1,116 @@
+package result_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func newBaselineResultList() ResultList {
+	rl := NewResultList(false)
+	rl.AddResult(Result{
+		Name:      "modules",
+		Severity:  "high",
+		CheckType: "yaml",
+		Status:    Fail,
+		Breaches: []breach.Breach{
+			&breach.ValueBreach{CheckName: "modules", ValueLabel: "disallowed", Value: "devel"},
+			&breach.ValueBreach{CheckName: "modules", ValueLabel: "disallowed", Value: "views_ui"},
+		},
+	})
+	rl.AddResult(Result{
+		Name:      "images",
+		Severity:  "normal",
+		CheckType: "yaml",
+		Status:    Fail,
+		Breaches: []breach.Breach{
+			&breach.KeyValueBreach{CheckName: "images", Key: "nginx", Value: "nginx:latest"},
+		},
+	})
+	return rl
+}
+
+func TestNewBaseline(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := newBaselineResultList()
+	b := NewBaseline(&rl)
+	assert.Equal(BaselineVersion, b.Version)
+	assert.Len(b.Breaches, 3)
+	assert.Equal("images", b.Breaches[0].CheckName)
+	assert.Equal("[:nginx] : nginx:latest", b.Breaches[0].Breach)
+	assert.Equal(breach.Fingerprint(rl.Results[1].Breaches[0]), b.Breaches[0].Fingerprint)
+	assert.Equal("modules", b.Breaches[1].CheckName)
+	assert.Equal("modules", b.Breaches[2].CheckName)
+}
+
+func TestBaselineSaveRead(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := newBaselineResultList()
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	assert.NoError(NewBaseline(&rl).Save(path))
+
+	b, err := ReadBaseline(path)
+	assert.NoError(err)
+	assert.Len(b.Breaches, 3)
+
+	t.Run("notFound", func(t *testing.T) {
+		_, err := ReadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorIs(err, os.ErrNotExist)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		assert.NoError(os.WriteFile(path, []byte("foo"), 0644))
+		_, err := ReadBaseline(path)
+		assert.ErrorContains(err, "failed to parse baseline")
+	})
+
+	t.Run("unsupportedVersion", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		assert.NoError(os.WriteFile(path, []byte(`{"version": 99}`), 0644))
+		_, err := ReadBaseline(path)
+		assert.EqualError(err, "unsupported baseline version 99, expected 1")
+	})
+}
+
+func TestApplyBaseline(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := newBaselineResultList()
+	b := NewBaseline(&rl)
+	// Only keep the devel & nginx breaches in the baseline.
+	b.Breaches = []BaselineBreach{b.Breaches[0]}
+	b.Breaches = append(b.Breaches, BaselineBreach{
+		Fingerprint: breach.Fingerprint(rl.Results[0].Breaches[0])})
+
+	rl.ApplyBaseline(b)
+	assert.True(rl.Results[0].Breaches[0].IsSuppressed())
+	assert.False(rl.Results[0].Breaches[1].IsSuppressed())
+	assert.True(rl.Results[1].Breaches[0].IsSuppressed())
+
+	assert.Equal(Fail, rl.Results[0].Status)
+	assert.Equal(Pass, rl.Results[1].Status)
+	assert.Equal(Fail, rl.Status())
+
+	assert.Equal(uint32(1), rl.TotalBreaches)
+	assert.Equal(uint32(2), rl.TotalSuppressed)
+	assert.Equal(map[string]int{"yaml": 1}, rl.BreachCountByType)
+	assert.Equal(map[string]int{"high": 1, "normal": 0}, rl.BreachCountBySeverity)
+	assert.Len(rl.GetBreachesBySeverity("high"), 1)
+	assert.Empty(rl.GetBreachesBySeverity("normal"))
+
+	t.Run("allSuppressed", func(t *testing.T) {
+		rl := newBaselineResultList()
+		rl.ApplyBaseline(NewBaseline(&rl))
+		assert.Equal(Pass, rl.Status())
+		assert.Equal(uint32(0), rl.TotalBreaches)
+		assert.Equal(uint32(3), rl.TotalSuppressed)
+	})
+}
@@ -0,0 +1,111 @@
+package result
+
+import (
+	"sort"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+)
+
+// Diff lists the differences between two result lists, e.g, the results of
+// successive runs.
+type Diff struct {
+	New      []DiffBreach `json:"new"`
+	Resolved []DiffBreach `json:"resolved"`
+	Changed  []DiffChange `json:"changed"`
+}
+
+// DiffBreach is a breach found in only one of the result lists.
+type DiffBreach struct {
+	Analyser    string        `json:"analyser"`
+	Severity    string        `json:"severity"`
+	Fingerprint string        `json:"fingerprint"`
+	Breach      breach.Breach `json:"breach"`
+}
+
+// DiffChange is an analyser whose status or severity changed.
+type DiffChange struct {
+	Analyser    string `json:"analyser"`
+	OldStatus   Status `json:"old-status"`
+	NewStatus   Status `json:"new-status"`
+	OldSeverity string `json:"old-severity"`
+	NewSeverity string `json:"new-severity"`
+}
+
+// Empty determines whether no difference was found.
+func (d *Diff) Empty() bool {
+	return len(d.New) == 0 && len(d.Resolved) == 0 && len(d.Changed) == 0
+}
+
+// NewDiff compares the active breaches of two result lists, using their
+// fingerprint, as well as the status and severity of the analysers found in
+// both.
+func NewDiff(old *ResultList, new *ResultList) *Diff {
+	d := &Diff{New: []DiffBreach{}, Resolved: []DiffBreach{}, Changed: []DiffChange{}}
+
+	oldBreaches := diffBreaches(old)
+	newBreaches := diffBreaches(new)
+	for fp, db := range newBreaches {
+		if _, ok := oldBreaches[fp]; !ok {
+			d.New = append(d.New, db)
+		}
+	}
+	for fp, db := range oldBreaches {
+		if _, ok := newBreaches[fp]; !ok {
+			d.Resolved = append(d.Resolved, db)
+		}
+	}
+	sortDiffBreaches(d.New)
+	sortDiffBreaches(d.Resolved)
+
+	oldResults := map[string]Result{}
+	for _, r := range old.Results {
+		oldResults[r.Name] = r
+	}
+	for _, r := range new.Results {
+		or, ok := oldResults[r.Name]
+		if !ok || (or.Status == r.Status && or.Severity == r.Severity) {
+			continue
+		}
+		d.Changed = append(d.Changed, DiffChange{
+			Analyser:    r.Name,
+			OldStatus:   or.Status,
+			NewStatus:   r.Status,
+			OldSeverity: or.Severity,
+			NewSeverity: r.Severity,
+		})
+	}
+	sort.Slice(d.Changed, func(i, j int) bool {
+		return d.Changed[i].Analyser < d.Changed[j].Analyser
+	})
+	return d
+}
+
+// diffBreaches maps the active breaches of the result list by fingerprint.
+func diffBreaches(rl *ResultList) map[string]DiffBreach {
+	breaches := map[string]DiffBreach{}
+	for _, r := range rl.Results {
+		for _, b := range r.ActiveBreaches() {
+			severity := b.GetSeverity()
+			if severity == "" {
+				severity = r.Severity
+			}
+			fp := breach.Fingerprint(b)
+			breaches[fp] = DiffBreach{
+				Analyser:    r.Name,
+				Severity:    severity,
+				Fingerprint: fp,
+				Breach:      b,
+			}
+		}
+	}
+	return breaches
+}
+
+func sortDiffBreaches(breaches []DiffBreach) {
+	sort.Slice(breaches, func(i, j int) bool {
+		if breaches[i].Analyser != breaches[j].Analyser {
+			return breaches[i].Analyser < breaches[j].Analyser
+		}
+		return breaches[i].Breach.String() < breaches[j].Breach.String()
+	})
+}
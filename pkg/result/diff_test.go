@@ -0,0 +1,61 @@
+package result_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestNewDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	devel := &breach.ValueBreach{CheckName: "modules", Value: "devel"}
+	viewsUi := &breach.ValueBreach{CheckName: "modules", Value: "views_ui"}
+	nginx := &breach.KeyValueBreach{
+		CheckName: "images", Severity: "high", Key: "nginx", Value: "nginx:latest"}
+
+	old := NewResultList(false)
+	old.AddResult(Result{Name: "modules", Severity: "normal", Status: Fail,
+		Breaches: []breach.Breach{devel, viewsUi}})
+	old.AddResult(Result{Name: "images", Severity: "normal", Status: Pass})
+	old.AddResult(Result{Name: "tfa", Severity: "normal", Status: Pass})
+
+	new := NewResultList(false)
+	new.AddResult(Result{Name: "modules", Severity: "high", Status: Fail,
+		Breaches: []breach.Breach{
+			// Moving a breach doesn't make it new.
+			&breach.ValueBreach{CheckName: "modules", Value: "devel",
+				Location: &breach.Location{File: "core.extension.yml", Line: 4}},
+		}})
+	new.AddResult(Result{Name: "images", Severity: "normal", Status: Fail,
+		Breaches: []breach.Breach{nginx}})
+	new.AddResult(Result{Name: "tfa", Severity: "normal", Status: Pass,
+		Breaches: []breach.Breach{&breach.ValueBreach{
+			CheckName: "tfa", Value: "disabled", Waiver: &breach.Waiver{Reason: "SSO"}}}})
+
+	d := NewDiff(&old, &new)
+	assert.False(d.Empty())
+	assert.Equal([]DiffBreach{{
+		Analyser:    "images",
+		Severity:    "high",
+		Fingerprint: breach.Fingerprint(nginx),
+		Breach:      nginx,
+	}}, d.New)
+	assert.Equal([]DiffBreach{{
+		Analyser:    "modules",
+		Severity:    "normal",
+		Fingerprint: breach.Fingerprint(viewsUi),
+		Breach:      viewsUi,
+	}}, d.Resolved)
+	assert.Equal([]DiffChange{
+		{Analyser: "images", OldStatus: Pass, NewStatus: Fail,
+			OldSeverity: "normal", NewSeverity: "normal"},
+		{Analyser: "modules", OldStatus: Fail, NewStatus: Fail,
+			OldSeverity: "normal", NewSeverity: "high"},
+	}, d.Changed)
+
+	assert.True(NewDiff(&new, &new).Empty())
+}
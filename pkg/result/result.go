@@ -1,7 +1,11 @@
 package result
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"sort"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -18,7 +22,11 @@ const (
 
 // Result provides the structure for a Check's outcome.
 type Result struct {
+	// Analyser is the id of the analyser which produced the result, if any;
+	// the outputs identify the result by its name.
+	Analyser          string                        `json:"-"`
 	Name              string                        `json:"name"`
+	Description       string                        `json:"description,omitempty"`
 	Severity          string                        `json:"severity"`
 	CheckType         string                        `json:"check-type"`
 	Passes            []string                      `json:"passes"`
@@ -26,6 +34,36 @@ type Result struct {
 	Warnings          []string                      `json:"warnings"`
 	Status            Status                        `json:"status"`
 	RemediationStatus remediation.RemediationStatus `json:"remediation-status"`
+	// Links are urls with more information about the check or analyser.
+	Links []string `json:"links,omitempty"`
+	// Packages is whether the breaches' values are packages, e.g,
+	// "bitnami/kubectl:8.0", as with allowed:list's package-match.
+	Packages bool `json:"packages,omitempty"`
+	// Duration is how long the check or analyser took to run.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// UnmarshalJSON parses a result, creating each breach with the concrete type
+// given by its breach-type.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	type result Result
+	aux := struct {
+		*result
+		Breaches []json.RawMessage `json:"breaches"`
+	}{result: (*result)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.Breaches = nil
+	for i, raw := range aux.Breaches {
+		b, err := breach.Unmarshal(raw)
+		if err != nil {
+			return fmt.Errorf("invalid breach %d for '%s': %w", i, r.Name, err)
+		}
+		r.Breaches = append(r.Breaches, b)
+	}
+	return nil
 }
 
 // Sort reorders the Passes & Failures in order to get consistent output.
@@ -49,14 +87,14 @@ func (r *Result) Sort() {
 	}
 }
 
-func (r *Result) PerformRemediation() {
+func (r *Result) PerformRemediation(ctx context.Context) {
 	if len(r.Breaches) == 0 {
 		return
 	}
 
 	log.WithFields(r.LogFields()).Debug("performing remediation")
 	for _, b := range r.Breaches {
-		b.PerformRemediation()
+		b.PerformRemediation(ctx)
 	}
 
 }
@@ -112,13 +150,25 @@ func (r *Result) DetermineResultStatus(remediationPerformed bool) {
 	}
 
 	// Overall status.
-	if len(r.Breaches) > 0 {
+	if len(r.ActiveBreaches()) > 0 {
 		r.Status = Fail
 		return
 	}
 	r.Status = Pass
 }
 
+// ActiveBreaches returns the breaches which have neither been suppressed by
+// a baseline nor waived.
+func (r *Result) ActiveBreaches() []breach.Breach {
+	breaches := []breach.Breach{}
+	for _, b := range r.Breaches {
+		if !b.IsSuppressed() && b.GetWaiver() == nil {
+			breaches = append(breaches, b)
+		}
+	}
+	return breaches
+}
+
 func (r *Result) LogFields() log.Fields {
 	lf := log.Fields{
 		"name":               r.Name,
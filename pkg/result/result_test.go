@@ -1,6 +1,7 @@
 package result_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -282,3 +283,31 @@ func TestResultDetermineResultStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestResultUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Result{
+		Name:     "images",
+		Severity: "normal",
+		Status:   Fail,
+		Breaches: []breach.Breach{
+			&breach.ValueBreach{BreachType: breach.BreachTypeValue, Value: "foo"},
+			&breach.KeyValueBreach{
+				BreachType: breach.BreachTypeKeyValue,
+				Key:        "nginx",
+				Value:      "nginx:latest",
+				Location:   &breach.Location{File: "docker-compose.yml", Line: 3},
+			},
+		},
+	}
+	data, err := json.Marshal(r)
+	assert.NoError(err)
+
+	var unmarshalled Result
+	assert.NoError(json.Unmarshal(data, &unmarshalled))
+	assert.Equal(r, unmarshalled)
+
+	err = json.Unmarshal([]byte(`{"name":"images","breaches":[{"breach-type":"foo"}]}`), &unmarshalled)
+	assert.EqualError(err, "invalid breach 0 for 'images': unknown breach type 'foo'")
+}
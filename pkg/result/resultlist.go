@@ -1,6 +1,9 @@
 package result
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -11,14 +14,22 @@ import (
 	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
 )
 
+// ResultListVersion is the version of the result list's JSON document,
+// described by the schema published at ResultListSchemaId. Version 2 added
+// the results' description, duration, links and packages.
+const ResultListVersion = 2
+
 // ResultList is a wrapper around a list of results, providing some useful
 // methods to manipulate and use it.
 type ResultList struct {
+	Version int `json:"version"`
 	// Policies is a map of policy plugins to the policy names.
 	Policies              map[string][]string `json:"policies"`
 	RemediationPerformed  bool                `json:"remediation-performed"`
 	TotalChecks           uint32              `json:"total-checks"`
 	TotalBreaches         uint32              `json:"total-breaches"`
+	TotalSuppressed       uint32              `json:"total-suppressed,omitempty"`
+	TotalWaived           uint32              `json:"total-waived,omitempty"`
 	RemediationTotals     map[string]uint32   `json:"remediation-totals"`
 	CheckCountByType      map[string]int      `json:"check-count-by-type"`
 	BreachCountByType     map[string]int      `json:"breach-count-by-type"`
@@ -31,6 +42,7 @@ var lock = sync.RWMutex{}
 
 func NewResultList(remediate bool) ResultList {
 	rl := ResultList{
+		Version:               ResultListVersion,
 		RemediationPerformed:  remediate,
 		Results:               []Result{},
 		CheckCountByType:      map[string]int{},
@@ -40,6 +52,52 @@ func NewResultList(remediate bool) ResultList {
 	return rl
 }
 
+// ErrResultListVersion is returned when a result list's version is not
+// supported.
+type ErrResultListVersion struct {
+	Version int
+}
+
+func (e *ErrResultListVersion) Error() string {
+	return fmt.Sprintf("unsupported result list version %d, expected %d",
+		e.Version, ResultListVersion)
+}
+
+// UnmarshalJSON parses a result list, rejecting unsupported versions; lists
+// of a previous version, or without a version, written before it was
+// introduced, are upgraded since each version only added fields.
+func (rl *ResultList) UnmarshalJSON(data []byte) error {
+	type resultList ResultList
+	aux := resultList(NewResultList(false))
+	aux.Version = 0
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Version > ResultListVersion {
+		return &ErrResultListVersion{Version: aux.Version}
+	}
+	if aux.Version < ResultListVersion {
+		aux.Version = ResultListVersion
+	}
+	*rl = ResultList(aux)
+	return nil
+}
+
+// ReadResultList reads a result list from a file written using the json
+// output format.
+func ReadResultList(path string) (*ResultList, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := ResultList{}
+	if err := json.Unmarshal(content, &rl); err != nil {
+		return nil, fmt.Errorf("failed to parse results '%s': %w", path, err)
+	}
+	return &rl, nil
+}
+
 // IncrChecks increments the total checks count & checks count by type.
 func (rl *ResultList) IncrChecks(ct string, incr int) {
 	atomic.AddUint32(&rl.TotalChecks, uint32(incr))
@@ -57,12 +115,40 @@ func (rl *ResultList) AddResult(r Result) {
 	log.WithFields(r.LogFields()).Debug("adding final result")
 	rl.Results = append(rl.Results, r)
 
-	breachesIncr := len(r.Breaches)
+	rl.countBreaches(r)
+}
+
+// countBreaches adds the result's breaches to the totals; waived and
+// suppressed breaches are only counted in TotalWaived and TotalSuppressed.
+func (rl *ResultList) countBreaches(r Result) {
+	breachesIncr := len(r.ActiveBreaches())
+	waived := 0
+	for _, b := range r.Breaches {
+		if b.GetWaiver() != nil {
+			waived++
+		}
+	}
+	atomic.AddUint32(&rl.TotalWaived, uint32(waived))
+	atomic.AddUint32(&rl.TotalSuppressed, uint32(len(r.Breaches)-breachesIncr-waived))
 	atomic.AddUint32(&rl.TotalBreaches, uint32(breachesIncr))
 	rl.BreachCountByType[r.CheckType] = rl.BreachCountByType[r.CheckType] + breachesIncr
 	rl.BreachCountBySeverity[r.Severity] = rl.BreachCountBySeverity[r.Severity] + breachesIncr
 }
 
+// recount redetermines the results' status, then recalculates the totals.
+// The caller must hold the lock.
+func (rl *ResultList) recount() {
+	rl.TotalBreaches = 0
+	rl.TotalSuppressed = 0
+	rl.TotalWaived = 0
+	rl.BreachCountByType = map[string]int{}
+	rl.BreachCountBySeverity = map[string]int{}
+	for i := range rl.Results {
+		rl.Results[i].DetermineResultStatus(rl.RemediationPerformed)
+		rl.countBreaches(rl.Results[i])
+	}
+}
+
 // Status calculates and returns the overall result of all check results.
 func (rl *ResultList) Status() Status {
 	for _, r := range rl.Results {
@@ -130,13 +216,14 @@ func (rl *ResultList) GetBreachesByCheckName(cn string) []breach.Breach {
 	return breaches
 }
 
-// GetBreachesBySeverity fetches the list of failures by severity.
+// GetBreachesBySeverity fetches the list of failures by severity, ignoring
+// suppressed breaches.
 func (rl *ResultList) GetBreachesBySeverity(s string) []breach.Breach {
 	var breaches []breach.Breach
 
 	for _, r := range rl.Results {
 		if r.Severity == s {
-			breaches = append(breaches, r.Breaches...)
+			breaches = append(breaches, r.ActiveBreaches()...)
 		}
 	}
 	return breaches
@@ -1,6 +1,9 @@
 package result_test
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -398,3 +401,86 @@ func TestResultListSort(t *testing.T) {
 		{Name: "zcheck"},
 	}, rl.Results)
 }
+
+func TestReadResultList(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := NewResultList(false)
+	rl.AddResult(Result{
+		Name:     "modules",
+		Severity: "high",
+		Status:   Fail,
+		Breaches: []breach.Breach{&breach.ValueBreach{
+			BreachType: breach.BreachTypeValue, CheckName: "modules", Value: "devel"}},
+	})
+	data, err := json.Marshal(rl)
+	assert.NoError(err)
+	path := filepath.Join(t.TempDir(), "results.json")
+	assert.NoError(os.WriteFile(path, data, 0644))
+
+	read, err := ReadResultList(path)
+	assert.NoError(err)
+	assert.Equal(&rl, read)
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.NoError(os.WriteFile(path, []byte("foo"), 0644))
+		_, err := ReadResultList(path)
+		assert.ErrorContains(err, "failed to parse results")
+	})
+}
+
+func TestResultListUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := NewResultList(true)
+	rl.IncrChecks("yaml", 2)
+	rl.AddResult(Result{
+		Name:      "images",
+		Severity:  "normal",
+		CheckType: "yaml",
+		Status:    Fail,
+		Breaches: []breach.Breach{
+			&breach.KeyValueBreach{
+				BreachType: breach.BreachTypeKeyValue,
+				CheckName:  "images",
+				Key:        "nginx",
+				Value:      "nginx:latest",
+				Suppressed: true,
+			},
+			&breach.KeyValuesBreach{
+				BreachType: breach.BreachTypeKeyValues,
+				CheckName:  "images",
+				Key:        "php",
+				Values:     []string{"php:7", "php:8"},
+				RemediationResult: remediation.RemediationResult{
+					Status: remediation.RemediationStatusNoSupport},
+			},
+		},
+	})
+	rl.RemediationTotals = map[string]uint32{"unsupported": 1}
+	data, err := json.Marshal(rl)
+	assert.NoError(err)
+
+	var unmarshalled ResultList
+	assert.NoError(json.Unmarshal(data, &unmarshalled))
+	assert.Equal(rl, unmarshalled)
+
+	t.Run("noVersion", func(t *testing.T) {
+		var unmarshalled ResultList
+		assert.NoError(json.Unmarshal([]byte(`{"results": []}`), &unmarshalled))
+		assert.Equal(ResultListVersion, unmarshalled.Version)
+		assert.NotNil(unmarshalled.BreachCountByType)
+	})
+
+	t.Run("previousVersion", func(t *testing.T) {
+		var unmarshalled ResultList
+		assert.NoError(json.Unmarshal([]byte(`{"version": 1, "results": []}`), &unmarshalled))
+		assert.Equal(ResultListVersion, unmarshalled.Version)
+	})
+
+	t.Run("unsupportedVersion", func(t *testing.T) {
+		var unmarshalled ResultList
+		err := json.Unmarshal([]byte(`{"version": 99}`), &unmarshalled)
+		assert.EqualError(err, "unsupported result list version 99, expected 2")
+	})
+}
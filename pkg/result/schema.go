@@ -0,0 +1,54 @@
+package result
+
+import (
+	"reflect"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/schema"
+)
+
+// ResultListSchemaId is the URL at which the JSON Schema of the current
+// ResultListVersion is published.
+const ResultListSchemaId = "https://salsadigitalauorg.github.io/shipshape/schemas/results/v2.json"
+
+// Schema generates the JSON Schema of the result list's JSON document, as
+// written by the json output format.
+func Schema() *schema.Schema {
+	breaches := []*schema.Schema{}
+	for _, bt := range []struct {
+		t breach.BreachType
+		b breach.Breach
+	}{
+		{breach.BreachTypeValue, &breach.ValueBreach{}},
+		{breach.BreachTypeKeyValue, &breach.KeyValueBreach{}},
+		{breach.BreachTypeKeyValues, &breach.KeyValuesBreach{}},
+	} {
+		bs := schema.FromValue(bt.b, "json")
+		bs.Properties["breach-type"] = &schema.Schema{Const: string(bt.t)}
+		bs.Required = []string{"breach-type"}
+		breaches = append(breaches, bs)
+	}
+
+	// Result and ResultList implement their own unmarshalling, which the
+	// schema generation doesn't describe; use their fields instead.
+	type result Result
+	rs := schema.FromType(reflect.TypeOf(result{}), "json")
+	rs.Properties["breaches"] = &schema.Schema{
+		Type:  schema.Types{"array", "null"},
+		Items: &schema.Schema{AnyOf: breaches},
+	}
+	rs.Required = []string{"name", "status"}
+
+	type resultList ResultList
+	s := schema.FromType(reflect.TypeOf(resultList{}), "json")
+	s.Properties["results"] = &schema.Schema{
+		Type:  schema.Types{"array", "null"},
+		Items: rs,
+	}
+	s.Schema = schema.Draft
+	s.Id = ResultListSchemaId
+	s.Title = "Shipshape results"
+	s.Description = "Results of a shipshape run, as output using the json format."
+	s.Required = []string{"version", "results"}
+	return s
+}
@@ -0,0 +1,64 @@
+package result_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	. "github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := NewResultList(true)
+	rl.AddResult(Result{
+		Name:     "modules",
+		Severity: "high",
+		Status:   Fail,
+		Breaches: []breach.Breach{
+			&breach.ValueBreach{BreachType: breach.BreachTypeValue, Value: "devel",
+				Waiver: &breach.Waiver{Analyser: "modules", Reason: "dev"}},
+			&breach.KeyValueBreach{BreachType: breach.BreachTypeKeyValue,
+				Key: "nginx", Value: "nginx:latest",
+				Location: &breach.Location{File: "docker-compose.yml", Line: 3}},
+			&breach.KeyValuesBreach{BreachType: breach.BreachTypeKeyValues,
+				Key: "editor", Values: []string{"administer users"},
+				RemediationResult: remediation.RemediationResult{
+					Status: remediation.RemediationStatusFailed, Messages: []string{"failed"}}},
+		},
+	})
+	rl.AddResult(Result{Name: "tfa", Status: Pass, Passes: []string{"enabled"}})
+	data, err := json.Marshal(rl)
+	assert.NoError(err)
+
+	n := yaml.Node{}
+	assert.NoError(yaml.Unmarshal(data, &n))
+	assert.Empty(Schema().Validate(&n))
+
+	t.Run("unknownBreachType", func(t *testing.T) {
+		n := yaml.Node{}
+		assert.NoError(yaml.Unmarshal([]byte(
+			`{"version": 2, "results": [{"name": "a", "status": "Fail", "breaches": [{"breach-type": "foo"}]}]}`), &n))
+		assert.NotEmpty(Schema().Validate(&n))
+	})
+}
+
+// TestSchemaPublished ensures the published schema is kept up to date; it
+// can be updated using:
+//
+//	go run . config schema --results > docs/src/.vuepress/public/schemas/results/v2.json
+func TestSchemaPublished(t *testing.T) {
+	assert := assert.New(t)
+
+	published, err := os.ReadFile("../../docs/src/.vuepress/public/schemas/results/v2.json")
+	assert.NoError(err)
+	generated, err := json.MarshalIndent(Schema(), "", "  ")
+	assert.NoError(err)
+	assert.JSONEq(string(generated), string(published))
+}
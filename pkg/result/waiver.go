@@ -0,0 +1,75 @@
+package result
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+)
+
+// ApplyWaivers marks the breaches matching a waiver as waived, then
+// recalculates the results' status and the totals. Expired waivers don't
+// apply; they are returned, and a warning is added to the analyser's result.
+func (rl *ResultList) ApplyWaivers(waivers []breach.Waiver, now time.Time) []breach.Waiver {
+	lock.Lock()
+	defer lock.Unlock()
+
+	expired := []breach.Waiver{}
+	active := []breach.Waiver{}
+	for _, w := range waivers {
+		if w.Expired(now) {
+			expired = append(expired, w)
+			continue
+		}
+		active = append(active, w)
+	}
+
+	for i := range rl.Results {
+		r := &rl.Results[i]
+		for _, w := range expired {
+			if resultOfAnalyser(r, w.Analyser) {
+				r.Warnings = append(r.Warnings, fmt.Sprintf(
+					"waiver expired on %s: %s", w.Expires, w.Reason))
+			}
+		}
+		for _, b := range r.Breaches {
+			for _, w := range active {
+				if waiverMatches(r, w, b) {
+					waiver := w
+					b.SetWaiver(&waiver)
+					break
+				}
+			}
+		}
+	}
+	rl.recount()
+	return expired
+}
+
+// waiverMatches determines whether the waiver applies to the result's breach.
+// The breaches of results produced by analysers, e.g, legacy:check, may have
+// a different check name than the analyser's id.
+func waiverMatches(r *Result, w breach.Waiver, b breach.Breach) bool {
+	if r.Analyser != "" {
+		return r.Analyser == w.Analyser && w.MatchesKey(b)
+	}
+	return w.Matches(b)
+}
+
+// resultOfAnalyser determines whether the result is the analyser's. Results
+// not produced by analysers, e.g, of v1 checks, fall back to their name and
+// their breaches' check name.
+func resultOfAnalyser(r *Result, analyser string) bool {
+	if r.Analyser != "" {
+		return r.Analyser == analyser
+	}
+	if r.Name == analyser {
+		return true
+	}
+	for _, b := range r.Breaches {
+		if b.GetCheckName() == analyser {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package result_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	. "github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestApplyWaivers(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := newBaselineResultList()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	expired := rl.ApplyWaivers([]breach.Waiver{
+		{Analyser: "modules", Key: "devel", Reason: "Used on the dev environment", Owner: "devops"},
+		{Analyser: "images", Reason: "Pinned upstream", Expires: "2026-05-31"},
+	}, now)
+
+	assert.Equal([]breach.Waiver{
+		{Analyser: "images", Reason: "Pinned upstream", Expires: "2026-05-31"},
+	}, expired)
+	assert.Equal(&breach.Waiver{
+		Analyser: "modules", Key: "devel", Reason: "Used on the dev environment", Owner: "devops",
+	}, rl.Results[0].Breaches[0].GetWaiver())
+	assert.Nil(rl.Results[0].Breaches[1].GetWaiver())
+	assert.Nil(rl.Results[1].Breaches[0].GetWaiver())
+	assert.Equal([]string{"waiver expired on 2026-05-31: Pinned upstream"}, rl.Results[1].Warnings)
+
+	assert.Equal(uint32(2), rl.TotalBreaches)
+	assert.Equal(uint32(1), rl.TotalWaived)
+	assert.Equal(uint32(0), rl.TotalSuppressed)
+	assert.Len(rl.Results[0].ActiveBreaches(), 1)
+	assert.Equal(Fail, rl.Status())
+
+	t.Run("waivedNotBaselined", func(t *testing.T) {
+		assert.Len(NewBaseline(&rl).Breaches, 2)
+	})
+
+	t.Run("allWaived", func(t *testing.T) {
+		rl := newBaselineResultList()
+		rl.ApplyWaivers([]breach.Waiver{
+			{Analyser: "modules", Reason: "Accepted"},
+			{Analyser: "images", Reason: "Accepted", Expires: "2026-06-01"},
+		}, now)
+		assert.Equal(Pass, rl.Status())
+		assert.Equal(uint32(0), rl.TotalBreaches)
+		assert.Equal(uint32(3), rl.TotalWaived)
+	})
+}
+
+func TestApplyWaiversDescribedAnalyser(t *testing.T) {
+	t.Run("passing", func(t *testing.T) {
+		rl := NewResultList(false)
+		rl.AddResult(Result{
+			Analyser: "modules",
+			Name:     "Disallowed modules enabled",
+			Status:   Pass,
+		})
+		rl.ApplyWaivers([]breach.Waiver{
+			{Analyser: "modules", Reason: "Accepted", Expires: "2020-01-01"},
+		}, time.Now())
+		assert.Equal(t, []string{"waiver expired on 2020-01-01: Accepted"}, rl.Results[0].Warnings)
+	})
+
+	rl := NewResultList(false)
+	rl.AddResult(Result{
+		// Results are named after the analyser's description when set.
+		Name:     "Disallowed modules enabled",
+		Status:   Fail,
+		Breaches: []breach.Breach{&breach.ValueBreach{CheckName: "modules", Value: "devel"}},
+	})
+	rl.ApplyWaivers([]breach.Waiver{
+		{Analyser: "modules", Reason: "Accepted", Expires: "2020-01-01"},
+	}, time.Now())
+	assert.Equal(t, []string{"waiver expired on 2020-01-01: Accepted"}, rl.Results[0].Warnings)
+}
@@ -0,0 +1,204 @@
+// Package schema provides the generation of JSON Schemas from Go types and
+// their validation against yaml documents.
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Draft is the JSON Schema version of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, limited to the keywords required to describe
+// shipshape's configuration and results.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Id                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Const                string             `json:"const,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+
+	// never is set for the schema that no value validates against.
+	never bool
+}
+
+// Types is a list of JSON types, marshalled as a single string when there is
+// only one.
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Any returns a schema which any value validates against.
+func Any() *Schema {
+	return &Schema{}
+}
+
+// False returns a schema which no value validates against; it is used to
+// reject unknown properties.
+func False() *Schema {
+	return &Schema{never: true}
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.never {
+		return []byte("false"), nil
+	}
+	type rawSchema Schema
+	return json.Marshal((*rawSchema)(s))
+}
+
+// Object returns a schema for an object with the given properties, which
+// doesn't allow any other property.
+func Object(properties map[string]*Schema) *Schema {
+	return &Schema{
+		Type:                 Types{"object"},
+		Properties:           properties,
+		AdditionalProperties: False(),
+	}
+}
+
+// MapOf returns a schema for an object whose properties all validate against
+// the given schema.
+func MapOf(s *Schema) *Schema {
+	return &Schema{Type: Types{"object"}, AdditionalProperties: s}
+}
+
+// OneKeyOf returns a schema for an object with exactly one of the given
+// properties, as used for plugins configuration.
+func OneKeyOf(properties map[string]*Schema) *Schema {
+	s := Object(properties)
+	one := 1
+	s.MinProperties = &one
+	s.MaxProperties = &one
+	return s
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+var yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// FromType generates the schema of a type, using the given struct tag
+// ("yaml" or "json") to determine the name of the properties. Types which
+// implement their own unmarshalling are not described. Since nil slices and
+// maps are encoded as null in JSON, they are nullable when using "json".
+func FromType(t reflect.Type, tag string) *Schema {
+	return fromType(t, tag, map[reflect.Type]bool{})
+}
+
+// FromValue generates the schema of a value's type.
+func FromValue(v interface{}, tag string) *Schema {
+	return FromType(reflect.TypeOf(v), tag)
+}
+
+func fromType(t reflect.Type, tag string, visiting map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == durationType {
+		return &Schema{Type: Types{"string", "integer"}}
+	}
+	ptrT := reflect.PointerTo(t)
+	if (tag == "yaml" && ptrT.Implements(yamlUnmarshalerType)) ||
+		(tag == "json" && ptrT.Implements(jsonUnmarshalerType)) {
+		return Any()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}}
+		}
+		s := &Schema{
+			Type:  Types{"array"},
+			Items: fromType(t.Elem(), tag, visiting),
+		}
+		if tag == "json" {
+			s.Type = append(s.Type, "null")
+		}
+		return s
+	case reflect.Map:
+		s := MapOf(fromType(t.Elem(), tag, visiting))
+		if tag == "json" {
+			s.Type = append(s.Type, "null")
+		}
+		return s
+	case reflect.Struct:
+		if visiting[t] {
+			return Any()
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := map[string]*Schema{}
+		addStructFields(properties, t, tag, visiting)
+		return Object(properties)
+	}
+	return Any()
+}
+
+// addStructFields adds the properties for the fields of a struct, following
+// the encoding rules of the yaml and json packages.
+func addStructFields(properties map[string]*Schema, t reflect.Type, tag string, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagValue := field.Tag.Get(tag)
+		if tagValue == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tagValue, ",")
+		inline := (tag == "yaml" && strings.Contains(opts, "inline")) ||
+			(tag == "json" && field.Anonymous && name == "")
+		if inline {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructFields(properties, ft, tag, visiting)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+			if tag == "yaml" {
+				name = strings.ToLower(field.Name)
+			}
+		}
+		properties[name] = fromType(field.Type, tag, visiting)
+	}
+}
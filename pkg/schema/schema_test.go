@@ -0,0 +1,97 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/schema"
+)
+
+type testBase struct {
+	Id          string `yaml:"-"`
+	Description string `yaml:"description"`
+	internal    string
+}
+
+type testNested struct {
+	Key string `yaml:"key,omitempty"`
+}
+
+type testPlugin struct {
+	testBase `yaml:",inline"`
+	Path     string              `yaml:"path"`
+	Count    int                 `yaml:"count"`
+	Enabled  bool                `yaml:"enabled"`
+	Ratio    float64             `yaml:"ratio"`
+	Timeout  time.Duration       `yaml:"timeout"`
+	Values   []string            `yaml:"values"`
+	Labels   map[string]string   `yaml:"labels"`
+	Nested   testNested          `yaml:"nested"`
+	Anything interface{}         `yaml:"anything"`
+	Untagged string
+	Skipped  map[string][]string `yaml:"-"`
+}
+
+type testJsonPlugin struct {
+	Message string   `json:"msg"`
+	Args    []string `json:"args"`
+	Raw     string
+}
+
+func TestFromValue(t *testing.T) {
+	assert := assert.New(t)
+
+	s := FromValue(&testPlugin{}, "yaml")
+	sJson, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"description": {"type": "string"},
+			"path": {"type": "string"},
+			"count": {"type": "integer"},
+			"enabled": {"type": "boolean"},
+			"ratio": {"type": "number"},
+			"timeout": {"type": ["string", "integer"]},
+			"values": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"nested": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {"key": {"type": "string"}}
+			},
+			"anything": {},
+			"untagged": {"type": "string"}
+		}
+	}`, string(sJson))
+
+	s = FromValue(testJsonPlugin{}, "json")
+	sJson, err = json.Marshal(s)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"msg": {"type": "string"},
+			"args": {"type": ["array", "null"], "items": {"type": "string"}},
+			"Raw": {"type": "string"}
+		}
+	}`, string(sJson))
+}
+
+func TestOneKeyOf(t *testing.T) {
+	s := OneKeyOf(map[string]*Schema{"foo": Any()})
+	sJson, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"additionalProperties": false,
+		"minProperties": 1,
+		"maxProperties": 1,
+		"properties": {"foo": {}}
+	}`, string(sJson))
+}
@@ -0,0 +1,261 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is an error found when validating a document, along with
+// its location.
+type ValidationError struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	loc := fmt.Sprintf("%d", e.Line)
+	if e.Column > 0 {
+		loc = fmt.Sprintf("%d:%d", e.Line, e.Column)
+	}
+	if e.File != "" {
+		loc = e.File + ":" + loc
+	}
+	if e.Path != "" {
+		return fmt.Sprintf("%s: %s: %s", loc, e.Path, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", loc, e.Message)
+}
+
+// NewValidationError creates an error located at the given node.
+func NewValidationError(n *yaml.Node, path string, format string, a ...any) ValidationError {
+	return ValidationError{
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// SortErrors sorts errors by file and position.
+func SortErrors(errs []ValidationError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].File != errs[j].File {
+			return errs[i].File < errs[j].File
+		}
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+}
+
+// Validate validates a yaml node against the schema, returning every error
+// found.
+func (s *Schema) Validate(n *yaml.Node) []ValidationError {
+	return s.validate(n, "")
+}
+
+func (s *Schema) validate(n *yaml.Node, path string) []ValidationError {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return s.validate(n.Content[0], path)
+	case yaml.AliasNode:
+		return s.validate(n.Alias, path)
+	}
+
+	if s.never {
+		return []ValidationError{NewValidationError(n, path, "not allowed")}
+	}
+
+	// Null values are decoded as the zero value of any type.
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return nil
+	}
+
+	if len(s.AnyOf) > 0 {
+		// Report the errors of the closest option, preferring the options
+		// of the node's type.
+		var best []ValidationError
+		bestMatchesType := false
+		for _, option := range s.AnyOf {
+			errs := option.validate(n, path)
+			if len(errs) == 0 {
+				return nil
+			}
+			matchesType := len(option.Type) == 0 || option.matchesType(n)
+			if best == nil || (matchesType && !bestMatchesType) ||
+				(matchesType == bestMatchesType && len(errs) < len(best)) {
+				best = errs
+				bestMatchesType = matchesType
+			}
+		}
+		return best
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(n) {
+		return []ValidationError{NewValidationError(n, path,
+			"expected %s, got %s", strings.Join(s.Type, " or "), nodeType(n))}
+	}
+
+	if s.Const != "" && n.Value != s.Const {
+		return []ValidationError{NewValidationError(n, path,
+			"expected '%s', got '%s'", s.Const, n.Value)}
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, v := range s.Enum {
+			if n.Value == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return []ValidationError{NewValidationError(n, path,
+				"unsupported value '%s', expected one of: %s", n.Value,
+				strings.Join(s.Enum, ", "))}
+		}
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		return s.validateMapping(n, path)
+	case yaml.SequenceNode:
+		if s.Items == nil {
+			return nil
+		}
+		errs := []ValidationError{}
+		for i, item := range n.Content {
+			errs = append(errs, s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) validateMapping(n *yaml.Node, path string) []ValidationError {
+	errs := []ValidationError{}
+	count := len(n.Content) / 2
+	if s.MinProperties != nil && count < *s.MinProperties {
+		errs = append(errs, NewValidationError(n, path,
+			"expected at least %d key(s), got %d", *s.MinProperties, count))
+	}
+	if s.MaxProperties != nil && count > *s.MaxProperties {
+		errs = append(errs, NewValidationError(n, path,
+			"expected at most %d key(s), got %d", *s.MaxProperties, count))
+	}
+
+	for _, req := range s.Required {
+		found := false
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			if n.Content[i].Value == req {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, NewValidationError(n, path, "missing key '%s'", req))
+		}
+	}
+
+	for i := 0; i < len(n.Content)-1; i += 2 {
+		keyNode, valNode := n.Content[i], n.Content[i+1]
+		key := keyNode.Value
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		if propSchema, ok := s.Properties[key]; ok {
+			errs = append(errs, propSchema.validate(valNode, keyPath)...)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.never {
+			errs = append(errs, NewValidationError(keyNode, path,
+				"unknown key '%s'%s", key, s.expectedKeys()))
+			continue
+		}
+		errs = append(errs, s.AdditionalProperties.validate(valNode, keyPath)...)
+	}
+	return errs
+}
+
+// expectedKeys returns a hint listing the known properties.
+func (s *Schema) expectedKeys() string {
+	if len(s.Properties) == 0 {
+		return ""
+	}
+	keys := []string{}
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return ", expected one of: " + strings.Join(keys, ", ")
+}
+
+func (s *Schema) matchesType(n *yaml.Node) bool {
+	for _, t := range s.Type {
+		switch t {
+		case "object":
+			if n.Kind == yaml.MappingNode {
+				return true
+			}
+		case "array":
+			if n.Kind == yaml.SequenceNode {
+				return true
+			}
+		case "string":
+			// Any scalar can be decoded into a string.
+			if n.Kind == yaml.ScalarNode {
+				return true
+			}
+		case "boolean":
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!bool" {
+				return true
+			}
+		case "integer":
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!int" {
+				return true
+			}
+		case "number":
+			if n.Kind == yaml.ScalarNode && (n.Tag == "!!int" || n.Tag == "!!float") {
+				return true
+			}
+		case "null":
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func nodeType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.Tag {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	}
+	return "string"
+}
@@ -0,0 +1,182 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/schema"
+)
+
+func TestValidate(t *testing.T) {
+	s := MapOf(OneKeyOf(map[string]*Schema{
+		"test": FromValue(&testPlugin{}, "yaml"),
+		"other": {
+			AnyOf: []*Schema{
+				Object(map[string]*Schema{"plugin": {Const: "a"}, "foo": Any()}),
+				Object(map[string]*Schema{"plugin": {Const: "b"}, "bar": Any()}),
+			},
+		},
+		"level": {Type: Types{"string"}, Enum: []string{"low", "high"}},
+		"either": {
+			AnyOf: []*Schema{
+				Object(map[string]*Schema{"a": Any()}),
+				{Type: Types{"array"}, Items: &Schema{Type: Types{"string"}}},
+			},
+		},
+		"named": {
+			Type:       Types{"object"},
+			Properties: map[string]*Schema{"name": {Type: Types{"string"}}},
+			Required:   []string{"name"},
+		},
+	}))
+
+	tt := []struct {
+		name     string
+		doc      string
+		expected []string
+	}{
+		{
+			name: "valid",
+			doc: `
+foo:
+  test:
+    description: Foo
+    path: web
+    count: 3
+    enabled: true
+    ratio: 1.5
+    timeout: 1m
+    values: [a, 1]
+    labels:
+      foo: bar
+    nested:
+      key: baz
+    anything: [1, {a: b}]
+bar:
+  other:
+    plugin: b
+    bar: baz
+baz:
+  level: high
+disabled: ~
+`,
+		},
+		{
+			name: "unknownKeys",
+			doc: `
+foo:
+  test:
+    pth: web
+    nested:
+      value: baz
+`,
+			expected: []string{
+				"4:5: foo.test: unknown key 'pth', expected one of: anything, count, description, enabled, labels, nested, path, ratio, timeout, untagged, values",
+				"6:7: foo.test.nested: unknown key 'value', expected one of: key",
+			},
+		},
+		{
+			name: "wrongTypes",
+			doc: `
+foo:
+  test:
+    count: three
+    enabled: "yes"
+    values: a
+    labels: [a]
+`,
+			expected: []string{
+				"4:12: foo.test.count: expected integer, got string",
+				"5:14: foo.test.enabled: expected boolean, got string",
+				"6:13: foo.test.values: expected array, got string",
+				"7:13: foo.test.labels: expected object, got array",
+			},
+		},
+		{
+			name: "pluginCount",
+			doc: `
+foo:
+  test: {}
+  level: low
+bar: {}
+`,
+			expected: []string{
+				"3:3: foo: expected at most 1 key(s), got 2",
+				"5:6: bar: expected at least 1 key(s), got 0",
+			},
+		},
+		{
+			name: "enum",
+			doc: `
+foo:
+  level: medium
+`,
+			expected: []string{
+				"3:10: foo.level: unsupported value 'medium', expected one of: low, high",
+			},
+		},
+		{
+			name: "anyOf",
+			doc: `
+foo:
+  other:
+    plugin: a
+    bar: baz
+`,
+			expected: []string{
+				"5:5: foo.other: unknown key 'bar', expected one of: foo, plugin",
+			},
+		},
+		{
+			name: "anyOfType",
+			doc: `
+foo:
+  either: [a, {b: c}]
+`,
+			expected: []string{
+				"3:15: foo.either[1]: expected string, got object",
+			},
+		},
+		{
+			name: "required",
+			doc: `
+foo:
+  named:
+    other: bar
+`,
+			expected: []string{
+				"4:5: foo.named: missing key 'name'",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			n := yaml.Node{}
+			assert.NoError(t, yaml.Unmarshal([]byte(tc.doc), &n))
+			errs := s.Validate(&n)
+			SortErrors(errs)
+			errStrs := []string{}
+			for _, e := range errs {
+				errStrs = append(errStrs, e.Error())
+			}
+			if len(tc.expected) == 0 {
+				assert.Empty(t, errStrs)
+				return
+			}
+			assert.Equal(t, tc.expected, errStrs)
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	assert.Equal(t, "shipshape.yml:3:5: collect.foo: unknown key 'bar'",
+		ValidationError{
+			File: "shipshape.yml", Line: 3, Column: 5,
+			Path: "collect.foo", Message: "unknown key 'bar'",
+		}.Error())
+	assert.Equal(t, "1:1: not a mapping",
+		ValidationError{Line: 1, Column: 1, Message: "not a mapping"}.Error())
+}
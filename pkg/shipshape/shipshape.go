@@ -3,10 +3,12 @@
 package shipshape
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -27,6 +29,17 @@ var ErrorCodeOnFailure bool
 var Remediate bool
 var FailSeverity string
 
+// FromSnapshot is the path to a facts snapshot file to analyse instead of
+// collecting facts.
+var FromSnapshot string
+
+// BaselineFile is the path to a baseline of known breaches, which are then
+// suppressed rather than failing.
+var BaselineFile string
+
+// UpdateBaseline writes all the breaches found to BaselineFile.
+var UpdateBaseline bool
+
 // Config
 var IsV2 bool
 var RunConfig config.Config
@@ -114,39 +127,33 @@ func ProcessCheck(rl *result.ResultList, c config.Check) {
 		"check-name": c.GetName(),
 	})
 	contextLogger.Print("processing check")
-	if c.RequiresData() {
-		contextLogger.Print("fetching data")
-		c.FetchData()
-		c.HasData(true)
-		if len(c.GetResult().Breaches) == 0 {
-			c.UnmarshalDataMap()
-		}
-	}
-	if len(c.GetResult().Breaches) == 0 && len(c.GetResult().Passes) == 0 {
-		contextLogger.Print("running check")
-		c.RunCheck()
-	}
-	if len(c.GetResult().Breaches) > 0 && c.ShouldPerformRemediation() {
-		contextLogger.Print("performing remediation")
-		c.Remediate()
-	}
+	start := time.Now()
+	config.ExecuteCheck(c, contextLogger)
 	c.GetResult().DetermineResultStatus(c.ShouldPerformRemediation())
+	c.GetResult().Duration = time.Since(start)
 	contextLogger.
 		WithFields(log.Fields{"result": c.GetResult()}).
 		Print("check processed")
 	rl.AddResult(*c.GetResult())
 }
 
-func RunV2() {
+func RunV2(ctx context.Context) {
 	log.WithField("config", fmt.Sprintf("%+v", RunConfigV2)).Trace("running v2")
 
-	log.Print("parsing connections config")
-	if err := connection.Manager().ParseConfig(RunConfigV2.Connections); err != nil {
-		log.Fatal(err)
-	}
-	log.Print("parsing facts config")
-	if err := fact.Manager().ParseConfig(RunConfigV2.Collect); err != nil {
-		log.Fatal(err)
+	if FromSnapshot != "" {
+		log.WithField("snapshot", FromSnapshot).Print("loading facts snapshot")
+		if err := fact.Manager().LoadSnapshotFile(FromSnapshot); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Print("parsing connections config")
+		if err := connection.Manager().ParseConfig(RunConfigV2.Connections); err != nil {
+			log.Fatal(err)
+		}
+		log.Print("parsing facts config")
+		if err := fact.Manager().ParseConfig(RunConfigV2.Collect); err != nil {
+			log.Fatal(err)
+		}
 	}
 	log.Print("parsing analysers config")
 	if err := analyse.Manager().ParseConfig(RunConfigV2.Analyse); err != nil {
@@ -157,10 +164,13 @@ func RunV2() {
 	log.Print("parsing output config")
 	output.ParseConfig(RunConfigV2.Output, &RunResultList)
 
-	log.Print("collecting facts")
-	fact.Manager().CollectAllFacts()
+	if FromSnapshot == "" {
+		log.Print("collecting facts")
+		fact.Manager().CollectAllFacts(ctx)
+	}
 	if len(fact.Manager().GetErrors()) > 0 {
-		log.Fatal("failed to collect facts")
+		log.WithField("errors", fact.Manager().GetErrors()).
+			Fatal("failed to collect facts")
 	}
 
 	if FactsOnly {
@@ -174,13 +184,22 @@ func RunV2() {
 			Fatal("failed to validate analyser inputs")
 	}
 
+	for _, p := range analyse.Manager().GetPlugins() {
+		if sr, ok := p.(analyse.SelfRemediator); ok {
+			sr.SetPerformRemediation(Remediate)
+		}
+	}
+
 	log.Print("analysing facts")
 	results := analyse.Manager().AnalyseAll()
 
 	if Remediate {
 		log.Print("starting remediation")
-		for _, r := range results {
-			r.PerformRemediation()
+		for id, r := range results {
+			if _, ok := analyse.Manager().FindPlugin(id).(analyse.SelfRemediator); ok {
+				continue
+			}
+			r.PerformRemediation(ctx)
 		}
 	}
 
@@ -193,6 +212,48 @@ func RunV2() {
 	RunResultList.RemediationTotalsCount()
 }
 
+// ApplyWaivers marks the breaches matching the config's waivers as waived,
+// warning about the expired ones.
+func ApplyWaivers() {
+	if !IsV2 || len(RunConfigV2.Waivers) == 0 {
+		return
+	}
+
+	log.Print("applying waivers")
+	expired := RunResultList.ApplyWaivers(RunConfigV2.Waivers, time.Now())
+	for _, w := range expired {
+		log.WithFields(log.Fields{
+			"analyser": w.Analyser,
+			"key":      w.Key,
+			"owner":    w.Owner,
+			"expires":  w.Expires,
+		}).Warn("waiver has expired and no longer applies")
+	}
+}
+
+// ApplyBaseline suppresses the breaches found in the baseline file, after
+// first writing it from the current results when updating.
+func ApplyBaseline() error {
+	if BaselineFile == "" {
+		return nil
+	}
+
+	if UpdateBaseline {
+		log.WithField("baseline", BaselineFile).Print("updating baseline")
+		if err := result.NewBaseline(&RunResultList).Save(BaselineFile); err != nil {
+			return err
+		}
+	}
+
+	log.WithField("baseline", BaselineFile).Print("applying baseline")
+	b, err := result.ReadBaseline(BaselineFile)
+	if err != nil {
+		return err
+	}
+	RunResultList.ApplyBaseline(b)
+	return nil
+}
+
 func Exit(code int) {
 	if code > 0 && ErrorCodeOnFailure {
 		os.Exit(1)
@@ -2,6 +2,8 @@ package shipshape_test
 
 import (
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -56,6 +58,10 @@ func TestRunChecks(t *testing.T) {
 		string(testchecks.TestCheck1): 1,
 		string(testchecks.TestCheck2): 1,
 	}, RunResultList.BreachCountByType)
+	for i := range RunResultList.Results {
+		assert.NotZero(RunResultList.Results[i].Duration)
+		RunResultList.Results[i].Duration = 0
+	}
 	assert.ElementsMatch([]result.Result{
 		{
 			Name:      "test1stcheck",
@@ -89,3 +95,93 @@ func TestRunChecks(t *testing.T) {
 		}},
 		RunResultList.Results)
 }
+
+func TestApplyBaseline(t *testing.T) {
+	assert := assert.New(t)
+
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	defer func() {
+		BaselineFile = ""
+		UpdateBaseline = false
+	}()
+
+	newResultList := func() result.ResultList {
+		rl := result.NewResultList(false)
+		rl.AddResult(result.Result{
+			Name:     "modules",
+			Severity: "high",
+			Status:   result.Fail,
+			Breaches: []breach.Breach{&breach.ValueBreach{Value: "devel"}},
+		})
+		return rl
+	}
+
+	t.Run("noBaseline", func(t *testing.T) {
+		RunResultList = newResultList()
+		assert.NoError(ApplyBaseline())
+		assert.Equal(uint32(1), RunResultList.TotalBreaches)
+	})
+
+	t.Run("notFound", func(t *testing.T) {
+		BaselineFile = filepath.Join(t.TempDir(), "baseline.json")
+		RunResultList = newResultList()
+		assert.ErrorIs(ApplyBaseline(), os.ErrNotExist)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		BaselineFile = filepath.Join(t.TempDir(), "baseline.json")
+		UpdateBaseline = true
+		RunResultList = newResultList()
+		assert.NoError(ApplyBaseline())
+		assert.FileExists(BaselineFile)
+		assert.Equal(uint32(0), RunResultList.TotalBreaches)
+		assert.Equal(result.Pass, RunResultList.Status())
+
+		// The breach is suppressed in the following runs.
+		UpdateBaseline = false
+		RunResultList = newResultList()
+		assert.NoError(ApplyBaseline())
+		assert.Equal(uint32(1), RunResultList.TotalSuppressed)
+		assert.Equal(result.Pass, RunResultList.Status())
+	})
+}
+
+func TestApplyWaivers(t *testing.T) {
+	assert := assert.New(t)
+
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	defer func() {
+		IsV2 = false
+		RunConfigV2 = config.ConfigV2{}
+	}()
+
+	IsV2 = true
+	RunConfigV2 = config.ConfigV2{Waivers: []breach.Waiver{
+		{Analyser: "modules", Key: "devel", Reason: "Local development"},
+		{Analyser: "modules", Key: "views_ui", Reason: "Site building", Expires: "2020-01-01"},
+	}}
+	RunResultList = result.NewResultList(false)
+	RunResultList.AddResult(result.Result{
+		Name:     "modules",
+		Severity: "high",
+		Status:   result.Fail,
+		Breaches: []breach.Breach{
+			&breach.ValueBreach{CheckName: "modules", Value: "devel"},
+			&breach.ValueBreach{CheckName: "modules", Value: "views_ui"},
+		},
+	})
+
+	ApplyWaivers()
+	assert.Equal(uint32(1), RunResultList.TotalBreaches)
+	assert.Equal(uint32(1), RunResultList.TotalWaived)
+	assert.Equal("Local development", RunResultList.Results[0].Breaches[0].GetWaiver().Reason)
+	assert.Nil(RunResultList.Results[0].Breaches[1].GetWaiver())
+	assert.Equal([]string{"waiver expired on 2020-01-01: Site building"},
+		RunResultList.Results[0].Warnings)
+}
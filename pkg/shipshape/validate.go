@@ -0,0 +1,445 @@
+package shipshape
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/analyse"
+	"github.com/salsadigitalauorg/shipshape/pkg/breach"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/connection"
+	"github.com/salsadigitalauorg/shipshape/pkg/fact"
+	"github.com/salsadigitalauorg/shipshape/pkg/output"
+	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
+	"github.com/salsadigitalauorg/shipshape/pkg/remediation"
+	"github.com/salsadigitalauorg/shipshape/pkg/schema"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+)
+
+// ConfigSchema returns the JSON Schema of the v2 config, generated from the
+// registered plugins.
+func ConfigSchema() *schema.Schema {
+	connections := map[string]*schema.Schema{}
+	for name, factory := range connection.Manager().GetFactories() {
+		connections[name] = schema.FromValue(factory(""), "yaml")
+	}
+
+	facts := map[string]*schema.Schema{}
+	for name, factory := range fact.Manager().GetFactories() {
+		facts[name] = schema.FromValue(factory(""), "yaml")
+	}
+
+	severities := []string{
+		string(config.LowSeverity),
+		string(config.NormalSeverity),
+		string(config.HighSeverity),
+		string(config.CriticalSeverity),
+	}
+	analysers := map[string]*schema.Schema{}
+	for name, factory := range analyse.Manager().GetFactories() {
+		s := schema.FromValue(factory(""), "yaml")
+		if _, ok := s.Properties["severity"]; ok {
+			s.Properties["severity"] = &schema.Schema{
+				Type: schema.Types{"string"}, Enum: severities}
+		}
+		if _, ok := s.Properties["remediation"]; ok {
+			s.Properties["remediation"] = remediationSchema()
+		}
+		analysers[name] = s
+	}
+
+	outputs := map[string]*schema.Schema{}
+	for name, o := range output.Outputters {
+		outputs[name] = schema.FromValue(o, "yaml")
+	}
+
+	stringSchema := &schema.Schema{Type: schema.Types{"string"}}
+	s := schema.Object(map[string]*schema.Schema{
+		"include": {
+			Type: schema.Types{"array"},
+			Items: &schema.Schema{AnyOf: []*schema.Schema{
+				stringSchema,
+				schema.Object(map[string]*schema.Schema{
+					"path":       stringSchema,
+					"parameters": schema.MapOf(stringSchema),
+				}),
+			}},
+		},
+		"parameters":  schema.MapOf(stringSchema),
+		"connections": schema.MapOf(schema.OneKeyOf(connections)),
+		"collect":     schema.MapOf(schema.OneKeyOf(facts)),
+		"analyse":     schema.MapOf(schema.OneKeyOf(analysers)),
+		"output": {AnyOf: []*schema.Schema{
+			schema.Object(outputs),
+			{Type: schema.Types{"array"}, Items: schema.OneKeyOf(outputs)},
+		}},
+		"waivers": schema.FromType(reflect.TypeOf([]breach.Waiver{}), "yaml"),
+		// v1 checks are not described.
+		"checks": schema.Any(),
+	})
+	s.Schema = schema.Draft
+	s.Title = "Shipshape config"
+	return s
+}
+
+// remediationSchema returns the schema of an analyser's remediation, which
+// can use any of the registered remediation plugins; the plugin defaults to
+// command.
+func remediationSchema() *schema.Schema {
+	s := &schema.Schema{}
+	for _, name := range remediation.RegistryKeys() {
+		rs := schema.FromValue(remediation.Registry[name](), "json")
+		if rs.Properties == nil {
+			continue
+		}
+		rs.Properties["plugin"] = &schema.Schema{
+			Type: schema.Types{"string"}, Const: name}
+		s.AnyOf = append(s.AnyOf, rs)
+	}
+	return s
+}
+
+// configDocument is a parsed config file.
+type configDocument struct {
+	path string
+	node *yaml.Node
+}
+
+var yamlErrLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// ValidateConfig validates the config files against the schema generated
+// from the registered plugins, then checks the references between facts,
+// analysers and connections in the merged config. Every error found is
+// returned, sorted by file and position.
+func ValidateConfig(sources []config.Source) []schema.ValidationError {
+	errs := []schema.ValidationError{}
+	configSchema := ConfigSchema()
+	docs := []configDocument{}
+	for _, src := range sources {
+		n := &yaml.Node{}
+		if err := yaml.Unmarshal(src.Content, n); err != nil {
+			line := 0
+			if m := yamlErrLineRegex.FindStringSubmatch(err.Error()); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+			errs = append(errs, schema.ValidationError{
+				File: src.Path, Line: line, Message: err.Error()})
+			continue
+		}
+		docs = append(docs, configDocument{path: src.Path, node: n})
+
+		for _, e := range configSchema.Validate(n) {
+			e.File = src.Path
+			errs = append(errs, e)
+		}
+	}
+	if len(docs) != len(sources) {
+		schema.SortErrors(errs)
+		return errs
+	}
+
+	contents := [][]byte{}
+	for _, src := range sources {
+		contents = append(contents, src.Content)
+	}
+	isV2, _, cfg, err := config.ParseConfigData(contents)
+	if err != nil || !isV2 {
+		msg := "no fact or analyser found in the 'collect' and 'analyse' sections"
+		if err != nil {
+			msg = err.Error()
+		}
+		errs = append(errs, schema.ValidationError{
+			File: sources[0].Path, Line: 1, Column: 1, Message: msg})
+		schema.SortErrors(errs)
+		return errs
+	}
+
+	v := configValidator{docs: docs, reportDecodeErrors: len(errs) == 0}
+	errs = append(errs, v.validateReferences(cfg)...)
+	schema.SortErrors(errs)
+	return errs
+}
+
+type configValidator struct {
+	docs []configDocument
+	// reportDecodeErrors is false when the schema validation already
+	// reported errors, which would also cause plugins to fail decoding.
+	reportDecodeErrors bool
+}
+
+// newError creates an error located at the node found at the given path of
+// keys, or its closest parent, in the last file defining it.
+func (v *configValidator) newError(err error, keys ...string) schema.ValidationError {
+	for depth := len(keys); depth > 0; depth-- {
+		for i := len(v.docs) - 1; i >= 0; i-- {
+			if n := lookupKey(v.docs[i].node, keys[:depth]...); n != nil {
+				return schema.ValidationError{
+					File:    v.docs[i].path,
+					Line:    n.Line,
+					Column:  n.Column,
+					Path:    joinPath(keys[:depth-1]),
+					Message: err.Error(),
+				}
+			}
+		}
+	}
+	return schema.ValidationError{File: v.docs[0].path, Message: err.Error()}
+}
+
+// joinPath joins the keys of a path, e.g, "waivers[1].analyser".
+func joinPath(keys []string) string {
+	path := ""
+	for i, k := range keys {
+		if i > 0 && !strings.HasPrefix(k, "[") {
+			path += "."
+		}
+		path += k
+	}
+	return path
+}
+
+// lookupKey returns the key node at the given path; sequence items are
+// looked up using "[<index>]" keys.
+func lookupKey(n *yaml.Node, keys ...string) *yaml.Node {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	var keyNode *yaml.Node
+	for _, k := range keys {
+		// The item itself stands in for the key of a sequence item.
+		if n != nil && n.Kind == yaml.SequenceNode {
+			i, err := strconv.Atoi(strings.Trim(k, "[]"))
+			if err != nil || i < 0 || i >= len(n.Content) {
+				return nil
+			}
+			keyNode, n = n.Content[i], n.Content[i]
+			continue
+		}
+		if n == nil || n.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			if n.Content[i].Value == k {
+				keyNode, next = n.Content[i], n.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		n = next
+	}
+	return keyNode
+}
+
+// sortedIds returns the ids of a plugins config, sorted.
+func sortedIds(raw map[string]map[string]interface{}) []string {
+	ids := []string{}
+	for id := range raw {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// pluginName returns the plugin name of a plugin config.
+func pluginName(pluginConf map[string]interface{}) string {
+	for name := range pluginConf {
+		return name
+	}
+	return ""
+}
+
+// decodePlugin decodes a plugin's config into the plugin.
+func decodePlugin(conf interface{}, p interface{}) error {
+	// Not catching any errors when marshalling since the yaml content is known.
+	pluginYaml, _ := yaml.Marshal(conf)
+	return yaml.Unmarshal(pluginYaml, p)
+}
+
+func (v *configValidator) validateReferences(cfg config.ConfigV2) []schema.ValidationError {
+	errs := []schema.ValidationError{}
+
+	facts := map[string]fact.Facter{}
+	for _, id := range sortedIds(cfg.Collect) {
+		name := pluginName(cfg.Collect[id])
+		factory := fact.Manager().GetFactories()[name]
+		if factory == nil {
+			continue
+		}
+		f := factory(id)
+		if err := decodePlugin(cfg.Collect[id][name], f); err != nil {
+			if v.reportDecodeErrors {
+				errs = append(errs, v.newError(err, "collect", id, name))
+			}
+			continue
+		}
+		facts[id] = f
+	}
+
+	for _, id := range sortedIds(cfg.Collect) {
+		f, ok := facts[id]
+		if !ok {
+			continue
+		}
+		name := f.GetName()
+		for _, err := range validateFactConnection(f, cfg.Connections) {
+			errs = append(errs, v.newError(err, "collect", id, name, "connection"))
+		}
+		for _, err := range validateFactInput(f, facts) {
+			errs = append(errs, v.newError(err, "collect", id, name, "input"))
+		}
+		for _, n := range f.GetAdditionalInputNames() {
+			if _, ok := facts[n]; !ok {
+				errs = append(errs, v.newError(&plugin.ErrSupportNotFound{
+					Plugin: id, SupportType: "additional input", SupportPlugin: n},
+					"collect", id, name, "additional-inputs"))
+			}
+		}
+	}
+
+	if _, err := fact.NewDependencyGraph(facts); err != nil {
+		var circErr *fact.ErrCircularDependency
+		if errors.As(err, &circErr) && len(circErr.Path) > 0 {
+			id := circErr.Path[0]
+			errs = append(errs, v.newError(err, "collect", id,
+				pluginName(cfg.Collect[id]), "input"))
+		} else {
+			errs = append(errs, v.newError(err, "collect"))
+		}
+	}
+
+	for _, id := range sortedIds(cfg.Analyse) {
+		name := pluginName(cfg.Analyse[id])
+		factory := analyse.Manager().GetFactories()[name]
+		if factory == nil {
+			continue
+		}
+		a := factory(id)
+		if err := decodePlugin(cfg.Analyse[id][name], a); err != nil {
+			if v.reportDecodeErrors {
+				errs = append(errs, v.newError(err, "analyse", id, name))
+			}
+			continue
+		}
+
+		// Legacy checks fetch their own data.
+		if legacy, ok := a.(*analyse.LegacyCheck); ok {
+			if _, err := legacy.NewCheck(); err != nil {
+				errs = append(errs, v.newError(err, "analyse", id, name, "type"))
+			}
+			continue
+		}
+
+		if a.GetInputName() == "" {
+			errs = append(errs, v.newError(&plugin.ErrSupportRequired{
+				Plugin: id, SupportType: "input"}, "analyse", id, name))
+		} else if _, ok := facts[a.GetInputName()]; !ok {
+			errs = append(errs, v.newError(&plugin.ErrSupportNotFound{
+				Plugin: id, SupportType: "input", SupportPlugin: a.GetInputName()},
+				"analyse", id, name, "input"))
+		}
+	}
+
+	for i, w := range cfg.Waivers {
+		errs = append(errs, v.validateWaiver(i, w, cfg.Analyse)...)
+	}
+	return errs
+}
+
+// validateWaiver checks that the waiver's analyser exists, that it is
+// justified and that its expiry date is valid.
+func (v *configValidator) validateWaiver(i int, w breach.Waiver, analysers map[string]map[string]interface{}) []schema.ValidationError {
+	errs := []schema.ValidationError{}
+	index := fmt.Sprintf("[%d]", i)
+	if w.Analyser == "" {
+		errs = append(errs, v.newError(
+			fmt.Errorf("analyser required for waiver"), "waivers", index))
+	} else if _, ok := analysers[w.Analyser]; !ok {
+		errs = append(errs, v.newError(
+			fmt.Errorf("analyser '%s' not found for waiver", w.Analyser),
+			"waivers", index, "analyser"))
+	}
+	if w.Reason == "" {
+		errs = append(errs, v.newError(
+			fmt.Errorf("reason required for waiver of '%s'", w.Analyser),
+			"waivers", index))
+	}
+	if _, err := w.ExpiresAt(); err != nil {
+		errs = append(errs, v.newError(err, "waivers", index, "expires"))
+	}
+	return errs
+}
+
+// validateFactConnection checks that the fact's connection exists and is
+// supported by the fact.
+func validateFactConnection(f fact.Facter, connections map[string]map[string]interface{}) []error {
+	level, supported := f.SupportedConnections()
+	connName := f.GetConnectionName()
+	if connName == "" {
+		if level == plugin.SupportRequired {
+			return []error{&plugin.ErrSupportRequired{
+				Plugin: f.GetId(), SupportType: "connection"}}
+		}
+		return nil
+	}
+
+	connConf, ok := connections[connName]
+	if !ok {
+		return []error{&plugin.ErrSupportNotFound{
+			Plugin: f.GetId(), SupportType: "connection", SupportPlugin: connName}}
+	}
+	if connPlugin := pluginName(connConf); !utils.StringSliceContains(supported, connPlugin) {
+		return []error{&plugin.ErrSupportNone{
+			Plugin: f.GetId(), SupportType: "connection", SupportPlugin: connPlugin}}
+	}
+	return nil
+}
+
+// validateFactInput checks that the fact's input exists and, when its format
+// is known before collection, that it is supported by the fact.
+func validateFactInput(f fact.Facter, facts map[string]fact.Facter) []error {
+	level, formats := f.SupportedInputFormats()
+	inputName := f.GetInputName()
+	if inputName == "" {
+		if level == plugin.SupportRequired {
+			return []error{&plugin.ErrSupportRequired{
+				Plugin: f.GetId(), SupportType: "input"}}
+		}
+		return nil
+	}
+
+	if level == plugin.SupportNone && len(formats) == 0 {
+		return []error{&plugin.ErrSupportNone{
+			Plugin: f.GetId(), SupportType: "input"}}
+	}
+
+	input, ok := facts[inputName]
+	if !ok {
+		return []error{&plugin.ErrSupportNotFound{
+			Plugin: f.GetId(), SupportType: "input", SupportPlugin: inputName}}
+	}
+
+	// Most facts only determine their format when collected.
+	if input.GetFormat() == "" {
+		return nil
+	}
+	for _, format := range formats {
+		if input.GetFormat() == format {
+			return nil
+		}
+	}
+	return []error{&plugin.ErrSupportNone{
+		Plugin:        f.GetId(),
+		SupportType:   "inputFormat",
+		SupportPlugin: string(input.GetFormat()),
+	}}
+}
@@ -0,0 +1,249 @@
+package shipshape_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/config/testdata/testchecks"
+	_ "github.com/salsadigitalauorg/shipshape/pkg/fact/docker"
+	_ "github.com/salsadigitalauorg/shipshape/pkg/fact/file"
+	_ "github.com/salsadigitalauorg/shipshape/pkg/fact/yaml"
+	. "github.com/salsadigitalauorg/shipshape/pkg/shipshape"
+)
+
+func TestConfigSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	s := ConfigSchema()
+	assert.Contains(s.Properties["collect"].AdditionalProperties.Properties, "file:read")
+	assert.Contains(s.Properties["analyse"].AdditionalProperties.Properties, "equals")
+	assert.Contains(s.Properties["connections"].AdditionalProperties.Properties, "mysql")
+	assert.Contains(s.Properties["output"].AnyOf[0].Properties, "stdout")
+	assert.Contains(s.Properties["output"].AnyOf[1].Items.Properties, "file")
+
+	equals := s.Properties["analyse"].AdditionalProperties.Properties["equals"]
+	assert.Equal([]string{"low", "normal", "high", "critical"},
+		equals.Properties["severity"].Enum)
+	assert.NotContains(equals.Properties, "result")
+
+	_, err := json.Marshal(s)
+	assert.NoError(err)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tt := []struct {
+		name     string
+		sources  []config.Source
+		expected []string
+	}{
+		{
+			name: "valid",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+collect:
+  core-extension:
+    file:read:
+      path: config/default/core.extension.yml
+  install-profile:
+    yaml:key:
+      input: core-extension
+      path: profile
+analyse:
+  wrong-install-profile:
+    equals:
+      input: install-profile
+      value: govcms
+      severity: high
+      remediation:
+        cmd: drush
+        args: [config:set, core.extension, profile, govcms]
+output:
+  stdout:
+    format: json
+`)}},
+		},
+		{
+			name: "notV2",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+checks:
+  file: []
+`)}},
+			expected: []string{
+				"shipshape.yml:1:1: no fact or analyser found in the 'collect' and 'analyse' sections",
+			},
+		},
+		{
+			name: "invalidYaml",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+collect:
+  foo: [
+`)}},
+			expected: []string{
+				"shipshape.yml:3: yaml: line 3: did not find expected node content",
+			},
+		},
+		{
+			name: "schemaErrors",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+collect:
+  core-extension:
+    file:read:
+      pth: config/default/core.extension.yml
+analyse:
+  wrong-install-profile:
+    equals:
+      input: core-extension
+      severity: extreme
+  unknown:
+    not:a:plugin: {}
+output:
+  stdot: {}
+`)}},
+			expected: []string{
+				"shipshape.yml:5:7: collect.core-extension.file:read: unknown key 'pth', expected one of: additional-inputs, connection, format, input, path, publish, timeout",
+				"shipshape.yml:10:17: analyse.wrong-install-profile.equals.severity: unsupported value 'extreme', expected one of: low, normal, high, critical",
+				"shipshape.yml:12:5: analyse.unknown: unknown key 'not:a:plugin', expected one of: allowed:list, equals, legacy:check, not:empty, not:equals, regex:match, regex:not-match",
+				"shipshape.yml:14:3: output: unknown key 'stdot', expected one of: file, lagoon, openmetrics, stdout, template, webhook",
+			},
+		},
+		{
+			name: "references",
+			sources: []config.Source{
+				{Path: "base.yml", Content: []byte(`
+connections:
+  db:
+    mysql:
+      host: localhost
+collect:
+  core-extension:
+    file:read:
+      path: config/default/core.extension.yml
+  install-profile:
+    yaml:key:
+      path: profile
+  files:
+    file:read:multiple:
+      input: core-extension
+  containers:
+    docker:command:
+      connection: db
+      command: [ls]
+analyse:
+  wrong-install-profile:
+    equals:
+      input: install-profile
+      value: govcms
+`)},
+				{Path: "override.yml", Content: []byte(`
+collect:
+  install-profile:
+    yaml:key:
+      input: core-extensio
+  loop-a:
+    yaml:key:
+      input: loop-b
+  loop-b:
+    yaml:key:
+      input: loop-a
+analyse:
+  wrong-install-profile:
+    equals:
+      input: install-profil
+`)},
+			},
+			expected: []string{
+				"base.yml:15:7: collect.files.file:read:multiple: inputFormat 'raw' not supported for 'files'",
+				"base.yml:18:7: collect.containers.docker:command: connection 'mysql' not supported for 'containers'",
+				"override.yml:5:7: collect.install-profile.yaml:key: input 'core-extensio' not found for 'install-profile'",
+				"override.yml:8:7: collect.loop-a.yaml:key: circular dependency detected between facts: loop-a -> loop-b -> loop-a",
+				"override.yml:15:7: analyse.wrong-install-profile.equals: input 'install-profil' not found for 'wrong-install-profile'",
+			},
+		},
+		{
+			name: "legacyChecks",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+analyse:
+  test:
+    legacy:check:
+      type: test-check-1
+      config:
+        foo: bar
+  unknown:
+    legacy:check:
+      type: not-a-check
+`)}},
+			expected: []string{
+				"shipshape.yml:10:7: analyse.unknown.legacy:check: check type 'not-a-check' not found for 'unknown'",
+			},
+		},
+		{
+			name: "waivers",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+collect:
+  modules:
+    file:read:
+      path: core.extension.yml
+analyse:
+  tfa-disabled:
+    not:empty:
+      input: modules
+waivers:
+  - analyser: tfa-disabled
+    reason: SSO enforces MFA
+    expires: 2026-12-31
+    owner: security
+  - analyser: tfa-disable
+    expires: 31/12/2026
+  - reason: Unknown
+    foo: bar
+`)}},
+			expected: []string{
+				"shipshape.yml:15:5: waivers[1]: analyser 'tfa-disable' not found for waiver",
+				"shipshape.yml:15:5: waivers: reason required for waiver of 'tfa-disable'",
+				"shipshape.yml:16:5: waivers[1]: invalid expiry date '31/12/2026' for waiver of 'tfa-disable', expected YYYY-MM-DD",
+				"shipshape.yml:17:5: waivers: analyser required for waiver",
+				"shipshape.yml:18:5: waivers[2]: unknown key 'foo', expected one of: analyser, expires, key, owner, reason",
+			},
+		},
+		{
+			name: "outputList",
+			sources: []config.Source{{Path: "shipshape.yml", Content: []byte(`
+collect:
+  modules:
+    file:read:
+      path: core.extension.yml
+output:
+  - stdout:
+      format: pretty
+  - file:
+      format: json
+      dir: reports
+      filename: "{{ .Date }}.{{ .Ext }}"
+  - file:
+      format: junit
+      pth: junit.xml
+`)}},
+			expected: []string{
+				"shipshape.yml:15:7: output[2].file: unknown key 'pth', expected one of: dir, filename, format, path",
+			},
+		},
+	}
+
+	testchecks.RegisterChecks()
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateConfig(tc.sources)
+			errStrs := []string{}
+			for _, e := range errs {
+				errStrs = append(errStrs, e.Error())
+			}
+			if len(tc.expected) == 0 {
+				assert.Empty(t, errStrs)
+				return
+			}
+			assert.Equal(t, tc.expected, errStrs)
+		})
+	}
+}
@@ -306,26 +306,34 @@ func StringSliceMatch(slice []string, item string) bool {
 	return false
 }
 
+var packageRegex = regexp.MustCompile("^(.[^:@]*)?[:@]?([^ latest$]*)")
+
+// ParsePackage splits a package string into its name and version, e.g,
+// "bitnami/kubectl" and "8.0" for "bitnami/kubectl:8.0".
+func ParsePackage(s string) (string, string) {
+	match := packageRegex.FindStringSubmatch(s)
+	if len(match) < 3 {
+		return "", ""
+	}
+	return match[1], match[2]
+}
+
 // Sift through a slice to determine if it contains eligible package
 // with optional version constrains.
 func PackageCheckString(slice []string, item string, item_version string) bool {
 	for _, s := range slice {
-		// Parse slice with regex to:
-		// 1 - package name (e.g. "bitnami/kubectl")
-		// 2 - version (e.g. "8.0")
-		service_regex := regexp.MustCompile("^(.[^:@]*)?[:@]?([^ latest$]*)")
-		service_match := service_regex.FindStringSubmatch(s)
+		name, pkgVersion := ParsePackage(s)
 		// Only proceed if package names were parsed successfully.
-		if len(service_match[1]) > 0 && len(item) > 0 {
+		if len(name) > 0 && len(item) > 0 {
 			// Check if package name matches.
-			if service_match[1] == item {
+			if name == item {
 				// Package name matched.
 				// If service does not dictate version than assume any version is allowed.
-				if len(service_match[2]) < 1 {
+				if len(pkgVersion) < 1 {
 					return true
 				} else if len(item_version) > 0 {
 					// Ensure that item version is not less than slice version.
-					allowedVersion, err := version.NewVersion(service_match[2])
+					allowedVersion, err := version.NewVersion(pkgVersion)
 					imageVersion, err := version.NewVersion(item_version)
 					// Run version comparison.
 					if err == nil && allowedVersion.LessThanOrEqual(imageVersion) {
@@ -478,6 +478,25 @@ func TestHasComposerDependency(t *testing.T) {
 	}
 }
 
+func TestParsePackage(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		pkg         string
+		wantName    string
+		wantVersion string
+	}{
+		{"bitnami/kubectl", "bitnami/kubectl", ""},
+		{"bitnami/kubectl:1.24", "bitnami/kubectl", "1.24"},
+		{"bitnami/postgresql@16", "bitnami/postgresql", "16"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		name, version := ParsePackage(tt.pkg)
+		assert.Equal(tt.wantName, name, tt.pkg)
+		assert.Equal(tt.wantVersion, version, tt.pkg)
+	}
+}
+
 func TestPackageCheckString(t *testing.T) {
 	assert := assert.New(t)
 	assert.False(PackageCheckString([]string{}, "bitnami/kubectl", ""))